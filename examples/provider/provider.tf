@@ -17,3 +17,49 @@ provider "mongodb" {
   username = "my-user"
   password = "my-password"
 }
+
+// With TLS certificates from other resources
+provider "mongodb" {
+  uri = "mongodb://localhost:27017"
+  tls = {
+    ca_pem   = tls_self_signed_cert.ca.cert_pem
+    cert_pem = tls_locally_signed_cert.client.cert_pem
+    key_pem  = tls_private_key.client.private_key_pem
+  }
+}
+
+// With X.509 client certificate authentication
+provider "mongodb" {
+  uri = "mongodb://localhost:27017"
+  tls = {
+    ca_pem    = file("ca.pem")
+    cert_pem  = file("client.pem")
+    key_pem   = file("client-key.pem")
+    x509_auth = true
+  }
+}
+
+// With user stored in another database than "admin"
+provider "mongodb" {
+  uri            = "mongodb://localhost:27017"
+  username       = "my-user"
+  password       = "my-password"
+  auth_source    = "my-db"
+  auth_mechanism = "SCRAM-SHA-256"
+}
+
+// With direct connection, such as before the replica set is initiated
+provider "mongodb" {
+  uri               = "mongodb://localhost:27017"
+  direct_connection = true
+}
+
+// With longer timeouts for large clusters under load
+provider "mongodb" {
+  uri             = "mongodb://localhost:27017"
+  connect_timeout = "1m"
+  default_timeouts = {
+    create = "5m"
+    update = "5m"
+  }
+}
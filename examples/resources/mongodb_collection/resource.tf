@@ -0,0 +1,26 @@
+resource "mongodb_collection" "example" {
+  db   = "my-app"
+  name = "customers"
+  collation = {
+    locale   = "en"
+    strength = 2
+  }
+  change_stream_pre_and_post_images = true
+}
+
+// Capped collection, keeping only the newest 10 MB of documents
+resource "mongodb_collection" "log" {
+  db     = "my-app"
+  name   = "log"
+  capped = true
+  size   = 10 * 1024 * 1024
+}
+
+// Clustered collection with documents expiring after a day
+resource "mongodb_collection" "sessions" {
+  db                        = "my-app"
+  name                      = "sessions"
+  clustered_index           = {}
+  expire_after_seconds      = 24 * 60 * 60
+  wired_tiger_config_string = "block_compressor=zstd"
+}
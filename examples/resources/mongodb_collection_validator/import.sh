@@ -0,0 +1,2 @@
+# Collection validators can be imported using the format "db.collection"
+terraform import mongodb_collection_validator.customers my-app.customers
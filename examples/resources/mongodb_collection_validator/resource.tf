@@ -0,0 +1,27 @@
+resource "mongodb_collection" "customers" {
+  db   = "my-app"
+  name = "customers"
+}
+
+// JSON Schema kept in a file next to the configuration
+resource "mongodb_collection_validator" "customers" {
+  db         = mongodb_collection.customers.db
+  collection = mongodb_collection.customers.name
+  validator = jsonencode({
+    "$jsonSchema" = jsondecode(file("${path.module}/schemas/customer.json"))
+  })
+  validation_level  = "moderate"
+  validation_action = "error"
+}
+
+// Validator using query operators
+resource "mongodb_collection_validator" "orders" {
+  db         = "my-app"
+  collection = "orders"
+  validator = jsonencode({
+    "$or" = [
+      { status = { "$in" = ["pending", "shipped"] } },
+      { shippedAt = { "$exists" = true } },
+    ]
+  })
+}
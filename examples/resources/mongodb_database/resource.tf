@@ -0,0 +1,9 @@
+resource "mongodb_database" "example" {
+  name = "my-app"
+}
+
+// Dropped on destroy, but only when it has no other collections
+resource "mongodb_database" "scratch" {
+  name            = "my-scratch-db"
+  drop_on_destroy = true
+}
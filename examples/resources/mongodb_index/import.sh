@@ -0,0 +1,4 @@
+# Indexes can be imported using the format "db.collection.index".
+# As both collection and index names may contain dots, the collection is
+# resolved by matching the ID against the existing collections.
+terraform import mongodb_index.example my-app.customers.lastName_1_age_-1
@@ -0,0 +1,28 @@
+resource "mongodb_index" "example" {
+  db         = "my-app"
+  collection = "customers"
+  keys = [
+    { field = "lastName" },
+    { field = "age", type = "-1" },
+  ]
+  unique = true
+  partial_filter_expression = jsonencode({
+    age = { "$gte" = 18 }
+  })
+}
+
+// TTL index, deleting sessions an hour after they were created
+resource "mongodb_index" "sessions_ttl" {
+  db                   = "my-app"
+  collection           = "sessions"
+  name                 = "expiry"
+  keys                 = [{ field = "createdAt" }]
+  expire_after_seconds = 3600
+}
+
+// Wildcard index on all fields in the "attributes" document
+resource "mongodb_index" "attributes" {
+  db         = "my-app"
+  collection = "products"
+  keys       = [{ field = "attributes.$**" }]
+}
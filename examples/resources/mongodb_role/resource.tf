@@ -23,3 +23,15 @@ resource "mongodb_role" "example" {
     { role = "read", db = "admin" },
   ]
 }
+
+// Users with this role may only authenticate from the app subnet
+resource "mongodb_role" "restricted" {
+  role = "myAppRole"
+  db   = "my-app"
+  roles = [
+    { role = "readWrite", db = "my-app" },
+  ]
+  authentication_restrictions = [
+    { client_source = ["10.0.0.0/16"] },
+  ]
+}
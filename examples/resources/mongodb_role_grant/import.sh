@@ -0,0 +1,3 @@
+# Inherited role grants can be imported using the format "db.role/roleDB.inheritedRole".
+# Privilege grants cannot be imported.
+terraform import mongodb_role_grant.reporting admin.shared-app-role/reporting.read
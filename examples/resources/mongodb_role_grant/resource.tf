@@ -0,0 +1,16 @@
+// Extend a shared role, that is managed elsewhere, with a privilege
+resource "mongodb_role_grant" "orders_reader" {
+  db   = "admin"
+  role = "shared-app-role"
+  privilege = {
+    resource = { db = "my-app", collection = "orders" }
+    actions  = ["find"]
+  }
+}
+
+// Make the shared role inherit the privileges of another role
+resource "mongodb_role_grant" "reporting" {
+  db             = "admin"
+  role           = "shared-app-role"
+  inherited_role = { role = "read", db = "reporting" }
+}
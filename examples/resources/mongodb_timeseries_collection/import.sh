@@ -0,0 +1,2 @@
+# Time series collections can be imported using the format "db.collection"
+terraform import mongodb_timeseries_collection.example my-app.weather
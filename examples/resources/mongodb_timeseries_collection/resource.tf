@@ -0,0 +1,29 @@
+resource "mongodb_timeseries_collection" "example" {
+  db                   = "my-app"
+  name                 = "weather"
+  time_field           = "timestamp"
+  meta_field           = "sensor"
+  granularity          = "minutes"
+  expire_after_seconds = 2592000 // 30 days
+}
+
+// Custom bucketing, requires MongoDB 6.3 or later
+resource "mongodb_timeseries_collection" "custom_buckets" {
+  db                      = "my-app"
+  name                    = "stock_prices"
+  time_field              = "time"
+  bucket_max_span_seconds = 300
+  bucket_rounding_seconds = 300
+}
+
+// Role that may read the raw buckets of the time series collection
+resource "mongodb_role" "weather_buckets_reader" {
+  db   = "my-app"
+  role = "weatherBucketsReader"
+  privileges = [
+    {
+      resource = { db = mongodb_timeseries_collection.example.db, system_buckets = mongodb_timeseries_collection.example.name }
+      actions  = ["find"]
+    },
+  ]
+}
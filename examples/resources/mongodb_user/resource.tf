@@ -4,6 +4,74 @@ resource "mongodb_user" "example" {
   pwd  = "super-secret-password"
 }
 
+// With write-only password, which is never stored in the Terraform state.
+// Requires Terraform 1.11 or later.
+resource "mongodb_user" "example" {
+  user   = "my-user"
+  db     = "my-db"
+  pwd_wo = var.my_user_password
+
+  // Increment to update the password of the user
+  pwd_wo_version = 1
+}
+
+// With generated password, available in the "pwd" attribute
+resource "mongodb_user" "example" {
+  user = "my-user"
+  db   = "my-db"
+
+  generate_password = {
+    length  = 24
+    special = true
+
+    // Changing any of the keepers generates a new password
+    keepers = {
+      rotated = "2026-01-01"
+    }
+  }
+}
+
+// Rotate the generated password every 90 days, alternating between two
+// users so the previous credentials stay valid for 7 days after a rotation.
+// Clients should use the "active_user" and "pwd" attributes.
+resource "mongodb_user" "example" {
+  user = "my-user"
+  db   = "my-db"
+
+  rotation_period         = "2160h" // 90 days
+  rotation_secondary_user = "my-user-secondary"
+  rotation_grace_period   = "168h" // 7 days
+}
+
+// Restore the password if it was changed outside of Terraform
+resource "mongodb_user" "example" {
+  user = "my-user"
+  db   = "my-db"
+  pwd  = "super-secret-password"
+
+  verify_password = true
+}
+
+// Digest the password in the provider, so the plaintext password is never
+// sent to the server. Only supported for SCRAM-SHA-1.
+resource "mongodb_user" "example" {
+  user = "my-user"
+  db   = "my-db"
+  pwd  = "super-secret-password"
+
+  mechanisms         = ["SCRAM-SHA-1"]
+  client_side_digest = true
+}
+
+// With precomputed password digest, from: echo -n "my-user:mongo:<password>" | md5sum
+resource "mongodb_user" "example" {
+  user       = "my-user"
+  db         = "my-db"
+  pwd_digest = "4b0df4a6ad2b95c0dd7d7a2cd8ea3c59"
+
+  mechanisms = ["SCRAM-SHA-1"]
+}
+
 // With role
 resource "mongodb_user" "example" {
   user = "my-user"
@@ -45,6 +113,46 @@ resource "mongodb_user" "example" {
   ]
 }
 
+// Only allow authenticating from the app subnets
+resource "mongodb_user" "example" {
+  user = "my-user"
+  db   = "my-db"
+  pwd  = "super-secret-password"
+
+  authentication_restrictions = [
+    {
+      client_source  = ["10.0.0.0/16", "10.1.0.0/16"]
+      server_address = ["10.2.0.0/24"]
+    },
+  ]
+}
+
+// X.509 certificate user, identified by the subject of the client certificate
+resource "mongodb_user" "example" {
+  user = "CN=my-client,OU=my-org-unit,O=my-org"
+  db   = "$external"
+
+  roles = [
+    {
+      role = "readWrite"
+      db   = "my-db"
+    },
+  ]
+}
+
+// AWS IAM user, identified by the ARN of the IAM role
+resource "mongodb_user" "example" {
+  user = "arn:aws:iam::123456789012:role/my-role"
+  db   = "$external"
+
+  roles = [
+    {
+      role = "read"
+      db   = "my-db"
+    },
+  ]
+}
+
 // With custom timeouts
 resource "mongodb_user" "example" {
   user = "my-user"
@@ -0,0 +1,2 @@
+# User role grants can be imported using the format "db.user/roleDB.role"
+terraform import mongodb_user_role_grant.reporting admin.app-backend/reporting.read
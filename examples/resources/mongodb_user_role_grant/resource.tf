@@ -0,0 +1,13 @@
+// Grant a role to a user that is managed elsewhere, such as by another team
+resource "mongodb_user_role_grant" "reporting" {
+  user = "app-backend"
+  db   = "admin"
+  role = { role = "read", db = "reporting" }
+}
+
+// Leave out the role's db to target the same database as the user
+resource "mongodb_user_role_grant" "cluster_monitor" {
+  user = "app-backend"
+  db   = "admin"
+  role = { role = "clusterMonitor" }
+}
@@ -0,0 +1,2 @@
+# Views can be imported using the format "db.view"
+terraform import mongodb_view.example my-app.activeCustomers
@@ -0,0 +1,21 @@
+resource "mongodb_view" "example" {
+  db      = "my-app"
+  name    = "activeCustomers"
+  view_on = "customers"
+  pipeline = jsonencode([
+    { "$match" = { status = "active" } },
+    { "$project" = { name = 1, email = 1 } },
+  ])
+}
+
+// Role that may only read the view, but not the underlying collection
+resource "mongodb_role" "active_customers_reader" {
+  db   = "my-app"
+  role = "activeCustomersReader"
+  privileges = [
+    {
+      resource = { db = mongodb_view.example.db, collection = mongodb_view.example.name }
+      actions  = ["find"]
+    },
+  ]
+}
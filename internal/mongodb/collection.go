@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Collection is a collection or view returned by the listCollections command.
+type Collection struct {
+	Name    string            `bson:"name"`
+	Type    string            `bson:"type"`
+	Options CollectionOptions `bson:"options"`
+}
+
+// Collection types returned by the listCollections command.
+const (
+	CollectionTypeCollection = "collection"
+	CollectionTypeView       = "view"
+	CollectionTypeTimeseries = "timeseries"
+)
+
+// CollectionOptions are the options of the create command, which are also
+// returned by the listCollections command.
+//
+// [https://www.mongodb.com/docs/manual/reference/command/create/]
+type CollectionOptions struct {
+	Capped                       bool                          `bson:"capped,omitempty"`
+	Size                         int64                         `bson:"size,omitempty"`
+	Max                          int64                         `bson:"max,omitempty"`
+	Collation                    *Collation                    `bson:"collation,omitempty"`
+	ClusteredIndex               *ClusteredIndex               `bson:"clusteredIndex,omitempty"`
+	ExpireAfterSeconds           *int64                        `bson:"expireAfterSeconds,omitempty"`
+	ChangeStreamPreAndPostImages *ChangeStreamPreAndPostImages `bson:"changeStreamPreAndPostImages,omitempty"`
+	StorageEngine                *StorageEngine                `bson:"storageEngine,omitempty"`
+	Timeseries                   *TimeseriesOptions            `bson:"timeseries,omitempty"`
+
+	// Validator is the document validation rules, such as a $jsonSchema.
+	Validator        any    `bson:"validator,omitempty"`
+	ValidationLevel  string `bson:"validationLevel,omitempty"`
+	ValidationAction string `bson:"validationAction,omitempty"`
+
+	// ViewOn is the source collection or view of a view.
+	ViewOn string `bson:"viewOn,omitempty"`
+	// Pipeline is the aggregation pipeline of a view.
+	Pipeline any `bson:"pipeline,omitempty"`
+}
+
+// Collation specifies language-specific rules for string comparison.
+//
+// [https://www.mongodb.com/docs/manual/reference/collation/]
+type Collation struct {
+	Locale          string `bson:"locale"`
+	CaseLevel       *bool  `bson:"caseLevel,omitempty"`
+	CaseFirst       string `bson:"caseFirst,omitempty"`
+	Strength        int    `bson:"strength,omitempty"`
+	NumericOrdering *bool  `bson:"numericOrdering,omitempty"`
+	Alternate       string `bson:"alternate,omitempty"`
+	MaxVariable     string `bson:"maxVariable,omitempty"`
+	Normalization   *bool  `bson:"normalization,omitempty"`
+	Backwards       *bool  `bson:"backwards,omitempty"`
+}
+
+// ClusteredIndex makes the collection clustered on the _id field, which must
+// be the key of the index and must be unique.
+//
+// [https://www.mongodb.com/docs/manual/core/clustered-collections/]
+type ClusteredIndex struct {
+	Key    bson.D `bson:"key"`
+	Unique bool   `bson:"unique"`
+	Name   string `bson:"name,omitempty"`
+}
+
+// NewClusteredIndex returns the only supported clustered index, on the _id field.
+func NewClusteredIndex(name string) *ClusteredIndex {
+	return &ClusteredIndex{
+		Key:    bson.D{{Key: "_id", Value: 1}},
+		Unique: true,
+		Name:   name,
+	}
+}
+
+// TimeseriesOptions makes the collection a time series collection. Either
+// Granularity or both BucketMaxSpanSeconds and BucketRoundingSeconds may be
+// set, but not both.
+//
+// [https://www.mongodb.com/docs/manual/core/timeseries-collections/]
+type TimeseriesOptions struct {
+	TimeField             string `bson:"timeField"`
+	MetaField             string `bson:"metaField,omitempty"`
+	Granularity           string `bson:"granularity,omitempty"`
+	BucketMaxSpanSeconds  *int64 `bson:"bucketMaxSpanSeconds,omitempty"`
+	BucketRoundingSeconds *int64 `bson:"bucketRoundingSeconds,omitempty"`
+}
+
+// Granularities of time series collections, ordered from finest to coarsest.
+// The granularity of an existing collection can only be made coarser.
+var Granularities = []string{
+	GranularitySeconds,
+	GranularityMinutes,
+	GranularityHours,
+}
+
+const (
+	GranularitySeconds = "seconds"
+	GranularityMinutes = "minutes"
+	GranularityHours   = "hours"
+)
+
+// Validation levels and actions of collections. The first of each is the
+// default used by MongoDB.
+//
+// [https://www.mongodb.com/docs/manual/core/schema-validation/]
+var (
+	ValidationLevels  = []string{ValidationLevelStrict, ValidationLevelModerate, ValidationLevelOff}
+	ValidationActions = []string{ValidationActionError, ValidationActionWarn}
+)
+
+const (
+	ValidationLevelStrict   = "strict"
+	ValidationLevelModerate = "moderate"
+	ValidationLevelOff      = "off"
+
+	ValidationActionError = "error"
+	ValidationActionWarn  = "warn"
+)
+
+// SystemBucketsPrefix is the prefix of the internal collections that store
+// the data of time series collections.
+const SystemBucketsPrefix = "system.buckets."
+
+type ChangeStreamPreAndPostImages struct {
+	Enabled bool `bson:"enabled"`
+}
+
+type StorageEngine struct {
+	WiredTiger *WiredTigerConfig `bson:"wiredTiger,omitempty"`
+}
+
+type WiredTigerConfig struct {
+	ConfigString string `bson:"configString,omitempty"`
+}
+
+func (c *Client) GetDBCollection(ctx context.Context, dbName, collName string) (Collection, error) {
+	if err := c.connect(ctx); err != nil {
+		return Collection{}, err
+	}
+	return c.runListCollectionsSingle(ctx, dbName, collName)
+}
+
+func (c *Client) runListCollectionsSingle(ctx context.Context, dbName, collName string) (Collection, error) {
+	colls, err := c.runListCollections(ctx, dbName, listCollectionsCommand{
+		ListCollections: 1,
+		Filter:          bson.D{{Key: "name", Value: collName}},
+	})
+	if err != nil {
+		return Collection{}, err
+	}
+	if len(colls) == 0 {
+		return Collection{}, ErrNotFound
+	}
+	return colls[0], nil
+}
+
+type NewCollection struct {
+	Name              string `bson:"create"`
+	CollectionOptions `bson:",inline"`
+}
+
+func (c *Client) CreateDBCollection(ctx context.Context, dbName string, newColl NewCollection) (Collection, error) {
+	if err := c.connect(ctx); err != nil {
+		return Collection{}, err
+	}
+	if err := c.runCreate(ctx, dbName, newColl); err != nil {
+		return Collection{}, err
+	}
+	return c.runListCollectionsSingle(ctx, dbName, newColl.Name)
+}
+
+// UpdateCollection is the collMod command. Only non-nil fields are sent.
+//
+// [https://www.mongodb.com/docs/manual/reference/command/collMod/]
+type UpdateCollection struct {
+	Name string `bson:"collMod"`
+	// ExpireAfterSeconds is either the number of seconds, or "off" to
+	// disable expiry.
+	ExpireAfterSeconds           any                           `bson:"expireAfterSeconds,omitempty"`
+	ChangeStreamPreAndPostImages *ChangeStreamPreAndPostImages `bson:"changeStreamPreAndPostImages,omitempty"`
+	CappedSize                   *int64                        `bson:"cappedSize,omitempty"`
+	CappedMax                    *int64                        `bson:"cappedMax,omitempty"`
+	Index                        *UpdateIndex                  `bson:"index,omitempty"`
+	Timeseries                   *UpdateTimeseries             `bson:"timeseries,omitempty"`
+	// Validator is removed by setting it to an empty document.
+	Validator        any    `bson:"validator,omitempty"`
+	ValidationLevel  string `bson:"validationLevel,omitempty"`
+	ValidationAction string `bson:"validationAction,omitempty"`
+	// ViewOn and Pipeline must both be set when changing a view.
+	ViewOn   string `bson:"viewOn,omitempty"`
+	Pipeline any    `bson:"pipeline,omitempty"`
+}
+
+// UpdateTimeseries changes the bucketing of a time series collection.
+type UpdateTimeseries struct {
+	Granularity string `bson:"granularity,omitempty"`
+}
+
+// ExpireAfterSecondsOff disables expiry in the collMod command.
+const ExpireAfterSecondsOff = "off"
+
+func (u UpdateCollection) hasChanges() bool {
+	return u.ExpireAfterSeconds != nil ||
+		u.ChangeStreamPreAndPostImages != nil ||
+		u.CappedSize != nil ||
+		u.CappedMax != nil ||
+		(u.Index != nil && u.Index.hasChanges()) ||
+		(u.Timeseries != nil && u.Timeseries.Granularity != "") ||
+		u.Validator != nil ||
+		u.ValidationLevel != "" ||
+		u.ValidationAction != "" ||
+		u.ViewOn != "" ||
+		u.Pipeline != nil
+}
+
+func (c *Client) UpdateDBCollection(ctx context.Context, dbName string, update UpdateCollection) (Collection, error) {
+	if err := c.connect(ctx); err != nil {
+		return Collection{}, err
+	}
+	if update.hasChanges() {
+		if err := c.runCollMod(ctx, dbName, update); err != nil {
+			return Collection{}, err
+		}
+	}
+	return c.runListCollectionsSingle(ctx, dbName, update.Name)
+}
+
+func (c *Client) runCollMod(ctx context.Context, dbName string, cmd any) error {
+	return c.runCommand(ctx, dbName, "collMod", cmd, nil, writeCmdOptions())
+}
+
+func (c *Client) DeleteDBCollection(ctx context.Context, dbName, collName string) error {
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+	return c.runCommand(ctx, dbName, "drop", bson.D{
+		{Key: "drop", Value: collName},
+	}, nil, writeCmdOptions())
+}
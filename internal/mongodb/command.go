@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	commandBackoffMin = 100 * time.Millisecond
+	commandBackoffMax = 5 * time.Second
+	// commandMaxAttempts limits how many times a command is run, so that
+	// persistent failures are reported before the context is done.
+	commandMaxAttempts = 10
+)
+
+// alreadyAppliedErrors are the errors that a command fails with when it is
+// repeated after it was already applied, keyed by command name.
+// Commands not listed here can safely be repeated.
+var alreadyAppliedErrors = map[string]error{
+	"createUser":  ErrUserExists,
+	"createRole":  ErrRoleExists,
+	"create":      ErrNamespaceExists,
+	"dropUser":    ErrNotFound,
+	"dropRole":    ErrNotFound,
+	"drop":        ErrNotFound,
+	"dropIndexes": ErrNotFound,
+}
+
+// runCommand runs a database command and decodes the response into the
+// response value, which may be nil. Transient failures are retried with an
+// exponential backoff, up to [commandMaxAttempts] times or until the context
+// is done.
+//
+// A failed attempt may still have been applied, such as when only the reply
+// was lost. If a retried command then fails because it was already applied,
+// such as with [ErrUserExists] for createUser, it is treated as a success.
+func (c *Client) runCommand(ctx context.Context, dbName, cmdName string, cmd, response any, opts ...*options.RunCmdOptions) error {
+	db := c.client.Database(dbName)
+	backoff := commandBackoffMin
+	for attempt := 1; ; attempt++ {
+		err := runCommandOnce(ctx, db, cmd, response, opts...)
+		if err != nil && attempt > 1 && isAlreadyAppliedError(cmdName, err) {
+			tflog.Warn(ctx, "Retried MongoDB command was already applied by an earlier attempt", map[string]any{
+				"command": cmdName,
+				"db":      dbName,
+				"attempt": attempt,
+				"error":   err.Error(),
+			})
+			return nil
+		}
+		if err == nil || !c.isTransientCommandError(err) {
+			return err
+		}
+		if attempt >= commandMaxAttempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		tflog.Warn(ctx, "Retrying MongoDB command after transient error", map[string]any{
+			"command": cmdName,
+			"db":      dbName,
+			"attempt": attempt,
+			"backoff": backoff.String(),
+			"error":   err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, commandBackoffMax)
+	}
+}
+
+func runCommandOnce(ctx context.Context, db *mongo.Database, cmd, response any, opts ...*options.RunCmdOptions) error {
+	raw, err := db.RunCommand(ctx, cmd, opts...).Raw()
+	if err != nil {
+		return wrapCommandError(err)
+	}
+	var status CommandResponse
+	if err := bson.Unmarshal(raw, &status); err != nil {
+		return err
+	}
+	if err := validateResponse(status); err != nil {
+		return err
+	}
+	if response == nil {
+		return nil
+	}
+	return bson.Unmarshal(raw, response)
+}
+
+func isAlreadyAppliedError(cmdName string, err error) bool {
+	sentinel, ok := alreadyAppliedErrors[cmdName]
+	return ok && errors.Is(err, sentinel)
+}
+
+// isTransientCommandError returns true for failures that are expected to go
+// away when retried, such as errors with the RetryableWriteError label and
+// replica set elections.
+func (c *Client) isTransientCommandError(err error) bool {
+	if errors.Is(err, ErrTransient) {
+		return true
+	}
+	// When connected directly to a single node, a new primary won't be
+	// discovered, so only retry when the driver can find the new primary.
+	direct := c.options.Direct != nil && *c.options.Direct
+	return errors.Is(err, ErrNotPrimary) && !direct
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsTransientCommandError(t *testing.T) {
+	direct := true
+	tests := []struct {
+		name    string
+		options Options
+		err     error
+		want    bool
+	}{
+		{
+			name: "conflicting operation",
+			err:  wrapCommandError(mongo.CommandError{Code: codeConflictingOperationInProgress}),
+			want: true,
+		},
+		{
+			name: "retryable write label",
+			err:  wrapCommandError(mongo.CommandError{Code: 12345, Labels: []string{labelRetryableWriteError}}),
+			want: true,
+		},
+		{
+			name: "not primary",
+			err:  wrapCommandError(mongo.CommandError{Code: codeNotWritablePrimary}),
+			want: true,
+		},
+		{
+			name:    "not primary with direct connection",
+			options: Options{Direct: &direct},
+			err:     wrapCommandError(mongo.CommandError{Code: codeNotWritablePrimary}),
+			want:    false,
+		},
+		{
+			name: "network error without retryable label",
+			err:  wrapCommandError(mongo.CommandError{Labels: []string{"NetworkError"}}),
+			want: false,
+		},
+		{
+			name: "user exists",
+			err:  wrapCommandError(mongo.CommandError{Code: codeUserAlreadyExists}),
+			want: false,
+		},
+		{
+			name: "unauthorized",
+			err:  wrapCommandError(mongo.CommandError{Code: codeUnauthorized}),
+			want: false,
+		},
+		{
+			name: "non-command error",
+			err:  errors.New("some error"),
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{options: tc.options}
+			if got := c.isTransientCommandError(tc.err); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsAlreadyAppliedError(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmdName string
+		err     error
+		want    bool
+	}{
+		{name: "createUser user exists", cmdName: "createUser", err: ErrUserExists, want: true},
+		{name: "createRole role exists", cmdName: "createRole", err: ErrRoleExists, want: true},
+		{name: "create namespace exists", cmdName: "create", err: ErrNamespaceExists, want: true},
+		{name: "dropUser not found", cmdName: "dropUser", err: ErrNotFound, want: true},
+		{name: "dropIndexes not found", cmdName: "dropIndexes", err: ErrNotFound, want: true},
+		{name: "createUser role exists", cmdName: "createUser", err: ErrRoleExists, want: false},
+		{name: "createUser not found", cmdName: "createUser", err: ErrNotFound, want: false},
+		{name: "updateUser not found", cmdName: "updateUser", err: ErrNotFound, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isAlreadyAppliedError(tc.cmdName, tc.err); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type Database struct {
+	Name       string `bson:"name"`
+	SizeOnDisk int64  `bson:"sizeOnDisk"`
+	Empty      bool   `bson:"empty"`
+}
+
+func (c *Client) GetDatabase(ctx context.Context, dbName string) (Database, error) {
+	if err := c.connect(ctx); err != nil {
+		return Database{}, err
+	}
+	return c.runListDatabasesSingle(ctx, dbName)
+}
+
+func (c *Client) runListDatabasesSingle(ctx context.Context, dbName string) (Database, error) {
+	dbs, err := c.runListDatabases(ctx, listDatabasesCommand{
+		ListDatabases: 1,
+		Filter:        bson.D{{Key: "name", Value: dbName}},
+	})
+	if err != nil {
+		return Database{}, err
+	}
+	if len(dbs) == 0 {
+		return Database{}, ErrNotFound
+	}
+	return dbs[0], nil
+}
+
+type listDatabasesCommand struct {
+	ListDatabases int `bson:"listDatabases"`
+	Filter        any `bson:"filter,omitempty"`
+}
+
+func (c *Client) runListDatabases(ctx context.Context, query listDatabasesCommand) ([]Database, error) {
+	var response struct {
+		Databases []Database `bson:"databases"`
+	}
+	if err := c.runCommand(ctx, "admin", "listDatabases", query, &response); err != nil {
+		return nil, err
+	}
+	return response.Databases, nil
+}
+
+// CreateDatabase ensures the database exists by creating the given collection
+// in it, as MongoDB only creates databases implicitly when storing data.
+// It is not an error if the collection already exists.
+func (c *Client) CreateDatabase(ctx context.Context, dbName, collName string) (Database, error) {
+	if err := c.connect(ctx); err != nil {
+		return Database{}, err
+	}
+	err := c.runCreate(ctx, dbName, bson.D{{Key: "create", Value: collName}})
+	if err != nil && !errors.Is(err, ErrNamespaceExists) {
+		return Database{}, err
+	}
+	return c.runListDatabasesSingle(ctx, dbName)
+}
+
+func (c *Client) runCreate(ctx context.Context, dbName string, cmd any) error {
+	return c.runCommand(ctx, dbName, "create", cmd, nil, writeCmdOptions())
+}
+
+func (c *Client) DropDatabase(ctx context.Context, dbName string) error {
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+	return c.runCommand(ctx, dbName, "dropDatabase", bson.D{
+		{Key: "dropDatabase", Value: 1},
+	}, nil, writeCmdOptions())
+}
+
+// ListCollectionNames returns the names of all collections and views in the
+// database, excluding system collections such as "system.views".
+// Fails if the user lacks the privilege to list all collections, instead of
+// only returning the collections that the user is authorized to access.
+func (c *Client) ListCollectionNames(ctx context.Context, dbName string) ([]string, error) {
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+	colls, err := c.runListCollections(ctx, dbName, listCollectionsCommand{
+		ListCollections: 1,
+		NameOnly:        true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, coll := range colls {
+		if !strings.HasPrefix(coll.Name, "system.") {
+			names = append(names, coll.Name)
+		}
+	}
+	return names, nil
+}
+
+type listCollectionsCommand struct {
+	ListCollections int  `bson:"listCollections"`
+	Filter          any  `bson:"filter,omitempty"`
+	NameOnly        bool `bson:"nameOnly,omitempty"`
+}
+
+func (c *Client) runListCollections(ctx context.Context, dbName string, query listCollectionsCommand) ([]Collection, error) {
+	var response struct {
+		Cursor cursorResponse[Collection] `bson:"cursor"`
+	}
+	if err := c.runCommand(ctx, dbName, "listCollections", query, &response); err != nil {
+		return nil, err
+	}
+	return runGetMore(ctx, c, dbName, response.Cursor)
+}
+
+// cursorResponse is the cursor returned by commands such as listCollections.
+type cursorResponse[T any] struct {
+	ID         int64  `bson:"id"`
+	NS         string `bson:"ns"`
+	FirstBatch []T    `bson:"firstBatch"`
+	NextBatch  []T    `bson:"nextBatch"`
+}
+
+// runGetMore returns all documents of the cursor, by reading the remaining
+// batches using the getMore command.
+//
+// The getMore command is not retried, as repeating it after a lost reply
+// would skip a batch, or fail as the cursor is exhausted.
+func runGetMore[T any](ctx context.Context, c *Client, dbName string, cursor cursorResponse[T]) ([]T, error) {
+	db := c.client.Database(dbName)
+	docs := cursor.FirstBatch
+	for cursor.ID != 0 {
+		_, collName, _ := strings.Cut(cursor.NS, ".")
+		var response struct {
+			Cursor cursorResponse[T] `bson:"cursor"`
+		}
+		if err := runCommandOnce(ctx, db, bson.D{
+			{Key: "getMore", Value: cursor.ID},
+			{Key: "collection", Value: collName},
+		}, &response); err != nil {
+			return nil, err
+		}
+		cursor = response.Cursor
+		docs = append(docs, cursor.NextBatch...)
+	}
+	return docs, nil
+}
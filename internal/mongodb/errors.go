@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrNotOK    = errors.New("not ok")
+
+	// ErrUserExists is returned when creating a user that already exists.
+	ErrUserExists = errors.New("user already exists")
+	// ErrRoleExists is returned when creating a role that already exists.
+	ErrRoleExists = errors.New("role already exists")
+	// ErrNamespaceExists is returned when creating a collection or view
+	// that already exists.
+	ErrNamespaceExists = errors.New("namespace already exists")
+	// ErrUnauthorized is returned when the authenticated user lacks the
+	// privileges required to run the command.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrAuthenticationFailed is returned when the server rejected the
+	// credentials.
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	// ErrNotPrimary is returned when a write was sent to a node that is not
+	// the primary of its replica set, or that stepped down while running it.
+	ErrNotPrimary = errors.New("not primary")
+	// ErrInvalidRoleModification is returned when trying to modify a built-in
+	// role, or when the change would introduce a cycle of inherited roles.
+	ErrInvalidRoleModification = errors.New("invalid role modification")
+	// ErrBadValue is returned when the server rejected a value in the command,
+	// such as an unknown privilege action or authentication mechanism.
+	ErrBadValue = errors.New("bad value")
+	// There is no ErrRoleInUse, as MongoDB has no such error to map:
+	// dropRole revokes the role from all users and roles that inherit it.
+
+	// ErrTransient is returned for failures that are expected to go away
+	// when retrying the command, such as errors with the RetryableWriteError
+	// label or with codes for unreachable hosts and conflicting operations.
+	ErrTransient = errors.New("transient error")
+)
+
+// Error codes returned by MongoDB commands.
+//
+// [https://github.com/mongodb/mongo/blob/master/src/mongo/base/error_codes.yml]
+const (
+	codeBadValue                        = 2
+	codeHostUnreachable                 = 6
+	codeHostNotFound                    = 7
+	codeFailedToParse                   = 9
+	codeUserNotFound                    = 11
+	codeUnauthorized                    = 13
+	codeAuthenticationFailed            = 18
+	codeNamespaceNotFound               = 26
+	codeIndexNotFound                   = 27
+	codeRoleNotFound                    = 31
+	codeNamespaceExists                 = 48
+	codeNetworkTimeout                  = 89
+	codeShutdownInProgress              = 91
+	codeInvalidRoleModification         = 92
+	codeConflictingOperationInProgress  = 117
+	codePrimarySteppedDown              = 189
+	codeNotWritablePrimary              = 10107
+	codeInterruptedDueToReplStateChange = 11602
+	codeNotPrimaryNoSecondaryOk         = 13435
+	codeNotPrimaryOrSecondary           = 13436
+	codeRoleAlreadyExists               = 51002
+	codeUserAlreadyExists               = 51003
+)
+
+// Error labels attached to MongoDB command errors.
+const (
+	labelRetryableWriteError = "RetryableWriteError"
+)
+
+// IsNotFound returns true if the error means that the targeted user, role,
+// namespace, or index does not exist. This includes both the [ErrNotFound]
+// sentinel error and MongoDB command errors with one of the "not found"
+// error codes.
+func IsNotFound(err error) bool {
+	return errors.Is(wrapCommandError(err), ErrNotFound)
+}
+
+// wrapConnectError wraps errors from the connection handshake where the
+// server rejected the credentials with [ErrAuthenticationFailed].
+//
+// The driver also wraps network errors during the authentication handshake
+// in an [auth.Error], so only errors with the AuthenticationFailed code from
+// the server are treated as rejected credentials.
+func wrapConnectError(err error) error {
+	var authErr *auth.Error
+	var driverErr driver.Error
+	if errors.As(err, &authErr) && errors.As(authErr, &driverErr) && driverErr.Code == codeAuthenticationFailed {
+		return fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+	}
+	return wrapCommandError(err)
+}
+
+// isTransientConnectError returns true if connecting may succeed when
+// retried, such as when the server is temporarily unreachable.
+func isTransientConnectError(err error) bool {
+	return !errors.Is(err, ErrAuthenticationFailed) &&
+		!errors.Is(err, ErrUnauthorized) &&
+		!errors.Is(err, context.Canceled)
+}
+
+// wrapCommandError wraps MongoDB command errors with one of the sentinel
+// errors in this package, such as [ErrUserExists], so they can be matched
+// using [errors.Is]. The original error is still available using [errors.As].
+func wrapCommandError(err error) error {
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) {
+		return err
+	}
+	if sentinel := commandErrorSentinel(cmdErr); sentinel != nil && !errors.Is(err, sentinel) {
+		return fmt.Errorf("%w: %w", sentinel, err)
+	}
+	return err
+}
+
+func commandErrorSentinel(err mongo.CommandError) error {
+	switch err.Code {
+	case codeUserNotFound, codeRoleNotFound, codeNamespaceNotFound, codeIndexNotFound:
+		return ErrNotFound
+	case codeUserAlreadyExists:
+		return ErrUserExists
+	case codeRoleAlreadyExists:
+		return ErrRoleExists
+	case codeNamespaceExists:
+		return ErrNamespaceExists
+	case codeUnauthorized:
+		return ErrUnauthorized
+	case codeAuthenticationFailed:
+		return ErrAuthenticationFailed
+	case codeNotWritablePrimary, codeNotPrimaryNoSecondaryOk, codeNotPrimaryOrSecondary,
+		codePrimarySteppedDown, codeInterruptedDueToReplStateChange:
+		return ErrNotPrimary
+	case codeInvalidRoleModification:
+		return ErrInvalidRoleModification
+	case codeBadValue, codeFailedToParse:
+		return ErrBadValue
+	case codeConflictingOperationInProgress, codeHostUnreachable, codeHostNotFound,
+		codeNetworkTimeout, codeShutdownInProgress:
+		return ErrTransient
+	}
+	if err.HasErrorLabel(labelRetryableWriteError) {
+		return ErrTransient
+	}
+	return nil
+}
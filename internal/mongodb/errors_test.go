@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+)
+
+func TestCommandErrorSentinel(t *testing.T) {
+	tests := []struct {
+		name string
+		err  mongo.CommandError
+		want error
+	}{
+		{name: "user not found", err: mongo.CommandError{Code: codeUserNotFound}, want: ErrNotFound},
+		{name: "role not found", err: mongo.CommandError{Code: codeRoleNotFound}, want: ErrNotFound},
+		{name: "namespace not found", err: mongo.CommandError{Code: codeNamespaceNotFound}, want: ErrNotFound},
+		{name: "index not found", err: mongo.CommandError{Code: codeIndexNotFound}, want: ErrNotFound},
+		{name: "user exists", err: mongo.CommandError{Code: codeUserAlreadyExists}, want: ErrUserExists},
+		{name: "role exists", err: mongo.CommandError{Code: codeRoleAlreadyExists}, want: ErrRoleExists},
+		{name: "namespace exists", err: mongo.CommandError{Code: codeNamespaceExists}, want: ErrNamespaceExists},
+		{name: "unauthorized", err: mongo.CommandError{Code: codeUnauthorized}, want: ErrUnauthorized},
+		{name: "authentication failed", err: mongo.CommandError{Code: codeAuthenticationFailed}, want: ErrAuthenticationFailed},
+		{name: "not writable primary", err: mongo.CommandError{Code: codeNotWritablePrimary}, want: ErrNotPrimary},
+		{name: "primary stepped down", err: mongo.CommandError{Code: codePrimarySteppedDown}, want: ErrNotPrimary},
+		{name: "invalid role modification", err: mongo.CommandError{Code: codeInvalidRoleModification}, want: ErrInvalidRoleModification},
+		{name: "bad value", err: mongo.CommandError{Code: codeBadValue}, want: ErrBadValue},
+		{name: "failed to parse", err: mongo.CommandError{Code: codeFailedToParse}, want: ErrBadValue},
+		{name: "conflicting operation", err: mongo.CommandError{Code: codeConflictingOperationInProgress}, want: ErrTransient},
+		{name: "host unreachable", err: mongo.CommandError{Code: codeHostUnreachable}, want: ErrTransient},
+		{name: "shutdown in progress", err: mongo.CommandError{Code: codeShutdownInProgress}, want: ErrTransient},
+		{
+			name: "retryable write label",
+			err:  mongo.CommandError{Code: 12345, Labels: []string{labelRetryableWriteError}},
+			want: ErrTransient,
+		},
+		{
+			name: "code takes precedence over label",
+			err:  mongo.CommandError{Code: codeUserAlreadyExists, Labels: []string{labelRetryableWriteError}},
+			want: ErrUserExists,
+		},
+		{
+			name: "transient transaction label",
+			err:  mongo.CommandError{Code: 12345, Labels: []string{"TransientTransactionError"}},
+			want: nil,
+		},
+		{
+			name: "network error label",
+			err:  mongo.CommandError{Labels: []string{"NetworkError"}},
+			want: nil,
+		},
+		{name: "unknown code", err: mongo.CommandError{Code: 12345}, want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := commandErrorSentinel(tc.err)
+			if got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWrapCommandError(t *testing.T) {
+	cmdErr := mongo.CommandError{Code: codeUserAlreadyExists, Message: "User already exists"}
+	err := wrapCommandError(cmdErr)
+	if !errors.Is(err, ErrUserExists) {
+		t.Errorf("want error to match ErrUserExists, got %v", err)
+	}
+	var gotCmdErr mongo.CommandError
+	if !errors.As(err, &gotCmdErr) || gotCmdErr.Code != codeUserAlreadyExists {
+		t.Errorf("want original command error to be kept, got %v", err)
+	}
+
+	plainErr := errors.New("some error")
+	if got := wrapCommandError(plainErr); got != plainErr {
+		t.Errorf("want non-command error to be returned as is, got %v", got)
+	}
+}
+
+func TestWrapConnectError(t *testing.T) {
+	// The inner error of an auth.Error cannot be set outside of the driver,
+	// so this is the same as a network error during the handshake.
+	if err := wrapConnectError(fmt.Errorf("connection failed: %w", &auth.Error{})); errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("want auth error without server code to not match ErrAuthenticationFailed, got %v", err)
+	}
+	if err := wrapConnectError(mongo.CommandError{Code: codeAuthenticationFailed}); !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("want error with AuthenticationFailed code to match ErrAuthenticationFailed, got %v", err)
+	}
+}
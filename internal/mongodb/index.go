@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Index is an index returned by the listIndexes command, and the index
+// specification of the createIndexes command.
+//
+// [https://www.mongodb.com/docs/manual/reference/command/createIndexes/]
+type Index struct {
+	Name                    string     `bson:"name"`
+	Key                     bson.D     `bson:"key"`
+	Unique                  bool       `bson:"unique,omitempty"`
+	Sparse                  bool       `bson:"sparse,omitempty"`
+	Hidden                  bool       `bson:"hidden,omitempty"`
+	PartialFilterExpression any        `bson:"partialFilterExpression,omitempty"`
+	ExpireAfterSeconds      *int64     `bson:"expireAfterSeconds,omitempty"`
+	Collation               *Collation `bson:"collation,omitempty"`
+	// Weights holds the fields of a text index, which are all merged into
+	// a single "_fts" field in the key.
+	Weights bson.D `bson:"weights,omitempty"`
+}
+
+func (c *Client) ListDBCollectionIndexes(ctx context.Context, dbName, collName string) ([]Index, error) {
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+	return c.runListIndexes(ctx, dbName, collName)
+}
+
+func (c *Client) GetDBCollectionIndex(ctx context.Context, dbName, collName, indexName string) (Index, error) {
+	if err := c.connect(ctx); err != nil {
+		return Index{}, err
+	}
+	return c.runListIndexesSingle(ctx, dbName, collName, indexName)
+}
+
+func (c *Client) runListIndexesSingle(ctx context.Context, dbName, collName, indexName string) (Index, error) {
+	indexes, err := c.runListIndexes(ctx, dbName, collName)
+	if err != nil {
+		return Index{}, err
+	}
+	for _, index := range indexes {
+		if index.Name == indexName {
+			return index, nil
+		}
+	}
+	return Index{}, ErrNotFound
+}
+
+func (c *Client) runListIndexes(ctx context.Context, dbName, collName string) ([]Index, error) {
+	var response struct {
+		Cursor cursorResponse[Index] `bson:"cursor"`
+	}
+	if err := c.runCommand(ctx, dbName, "listIndexes", bson.D{
+		{Key: "listIndexes", Value: collName},
+	}, &response); err != nil {
+		return nil, err
+	}
+	return runGetMore(ctx, c, dbName, response.Cursor)
+}
+
+// CreateDBCollectionIndex creates the index and waits for it to be built,
+// which may take a long time on large collections. The build continues on
+// the server even if the context is canceled.
+func (c *Client) CreateDBCollectionIndex(ctx context.Context, dbName, collName string, index Index) (Index, error) {
+	if err := c.connect(ctx); err != nil {
+		return Index{}, err
+	}
+	if err := c.runCommand(ctx, dbName, "createIndexes", bson.D{
+		{Key: "createIndexes", Value: collName},
+		{Key: "indexes", Value: []Index{index}},
+	}, nil, writeCmdOptions()); err != nil {
+		return Index{}, err
+	}
+	return c.runListIndexesSingle(ctx, dbName, collName, index.Name)
+}
+
+// UpdateIndex changes an index using the collMod command.
+// Only non-nil fields are sent.
+type UpdateIndex struct {
+	Name               string `bson:"name"`
+	Hidden             *bool  `bson:"hidden,omitempty"`
+	ExpireAfterSeconds *int64 `bson:"expireAfterSeconds,omitempty"`
+}
+
+func (u UpdateIndex) hasChanges() bool {
+	return u.Hidden != nil || u.ExpireAfterSeconds != nil
+}
+
+func (c *Client) UpdateDBCollectionIndex(ctx context.Context, dbName, collName string, update UpdateIndex) (Index, error) {
+	if err := c.connect(ctx); err != nil {
+		return Index{}, err
+	}
+	if update.hasChanges() {
+		if err := c.runCollMod(ctx, dbName, UpdateCollection{
+			Name:  collName,
+			Index: &update,
+		}); err != nil {
+			return Index{}, err
+		}
+	}
+	return c.runListIndexesSingle(ctx, dbName, collName, update.Name)
+}
+
+func (c *Client) DeleteDBCollectionIndex(ctx context.Context, dbName, collName, indexName string) error {
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+	return c.runCommand(ctx, dbName, "dropIndexes", bson.D{
+		{Key: "dropIndexes", Value: collName},
+		{Key: "index", Value: indexName},
+	}, nil, writeCmdOptions())
+}
@@ -6,67 +6,191 @@ package mongodb
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
-var (
-	ErrNotFound = errors.New("not found")
-	ErrNotOK    = errors.New("not ok")
+var AppName = "terraform-provider-mongodb-driver"
 
-	AppName = "terraform-provider-mongodb-driver"
-)
+// DBExternal is the virtual database of users authenticated by an external
+// source, such as X.509 certificates, LDAP, or Kerberos.
+const DBExternal = "$external"
 
 type Client struct {
 	uri         string
 	credentials Credentials
-	connectOnce sync.Once
-	client      *mongo.Client
-	connectErr  error
+	options     Options
+
+	// connectMu guards client, which is nil until connected successfully.
+	connectMu sync.Mutex
+	client    *mongo.Client
 }
 
 type Credentials struct {
 	Username string
 	Password string
+	// Mechanism overrides the authentication mechanism.
+	// When empty, the mechanism is negotiated with the server.
+	Mechanism Mechanism
+	// MechanismProperties are additional properties for the mechanism,
+	// such as SERVICE_NAME for GSSAPI.
+	MechanismProperties map[string]string
+	// Source is the database used to authenticate the user. When empty,
+	// defaults to "admin", or [DBExternal] for external mechanisms.
+	Source string
+}
+
+func (c Credentials) isEmpty() bool {
+	return c.Username == "" && c.Mechanism == "" && c.Source == "" && len(c.MechanismProperties) == 0
+}
+
+// applyTo overrides the credentials parsed from the connection URI.
+func (c Credentials) applyTo(opt *options.ClientOptions) {
+	if c.isEmpty() {
+		return
+	}
+	var cred options.Credential
+	if opt.Auth != nil {
+		cred = *opt.Auth
+	}
+	if c.Username != "" {
+		cred.Username = c.Username
+		cred.Password = c.Password
+		cred.PasswordSet = c.Password != ""
+	}
+	if c.Mechanism != "" {
+		cred.AuthMechanism = string(c.Mechanism)
+	}
+	if c.MechanismProperties != nil {
+		cred.AuthMechanismProperties = c.MechanismProperties
+	}
+	if c.Source != "" {
+		cred.AuthSource = c.Source
+	}
+	if cred.AuthMechanism == string(MechanismMONGODBX509) {
+		// The password must not be set. The username is optional, as the
+		// server defaults to the subject of the client certificate.
+		cred.Password = ""
+		cred.PasswordSet = false
+		if c.Source == "" {
+			cred.AuthSource = DBExternal
+		}
+	}
+	opt.SetAuth(cred)
+}
+
+// Options are additional connection settings that take precedence over the
+// settings in the connection URI.
+type Options struct {
+	// TLS overrides the TLS settings when set.
+	TLS *TLSConfig
+	// Direct overrides the "directConnection" option when set. When true, all
+	// commands are sent to the host in the URI without discovering the rest
+	// of the replica set.
+	Direct *bool
+	// ConnectRetries is how many times to retry connecting after a transient
+	// failure, such as when the server is unreachable.
+	ConnectRetries int
+	// ConnectTimeout limits how long connecting may take in total, including
+	// retries. When zero, connecting is only limited by the context.
+	ConnectTimeout time.Duration
 }
 
-func New(uri string, cred Credentials) *Client {
+func New(uri string, cred Credentials, opts Options) *Client {
 	return &Client{
 		uri:         uri,
 		credentials: cred,
+		options:     opts,
 	}
 }
 
+const (
+	connectBackoffMin = 500 * time.Millisecond
+	connectBackoffMax = 10 * time.Second
+)
+
+// connect lazily connects to MongoDB, retrying transient failures with an
+// exponential backoff. Failures are not cached, so the next call tries again.
 func (c *Client) connect(ctx context.Context) error {
-	c.connectOnce.Do(func() {
-		opt := options.Client().ApplyURI(c.uri).SetDirect(true)
-		opt.AppName = &AppName
-		if c.credentials.Username != "" {
-			opt.SetAuth(options.Credential{
-				Username:    c.credentials.Username,
-				Password:    c.credentials.Password,
-				PasswordSet: true,
-			})
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+	if c.client != nil {
+		return nil
+	}
+	opt, err := c.clientOptions()
+	if err != nil {
+		return err
+	}
+	if c.options.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.options.ConnectTimeout)
+		defer cancel()
+	}
+	backoff := connectBackoffMin
+	for attempt := 0; ; attempt++ {
+		client, err := tryConnect(ctx, opt)
+		if err == nil {
+			c.client = client
+			return nil
 		}
-
-		client, connectErr := mongo.Connect(ctx, opt)
-		if connectErr != nil {
-			c.connectErr = fmt.Errorf("connect: %w", connectErr)
-			return
+		if attempt >= c.options.ConnectRetries || !isTransientConnectError(err) {
+			return err
 		}
-		if err := client.Ping(ctx, readpref.PrimaryPreferred()); err != nil {
-			c.connectErr = fmt.Errorf("ping: %w", err)
-			return
+		tflog.Debug(ctx, "Retrying MongoDB connection", map[string]any{
+			"attempt": attempt + 1,
+			"backoff": backoff.String(),
+			"error":   err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, connectBackoffMax)
+	}
+}
+
+func (c *Client) clientOptions() (*options.ClientOptions, error) {
+	opt := options.Client().ApplyURI(c.uri)
+	opt.AppName = &AppName
+	if c.options.Direct != nil {
+		opt.SetDirect(*c.options.Direct)
+	}
+	c.credentials.applyTo(opt)
+	if c.options.TLS != nil {
+		if !c.options.TLS.Enabled {
+			opt.SetTLSConfig(nil)
+		} else {
+			tlsConfig, err := c.options.TLS.build(opt.TLSConfig)
+			if err != nil {
+				return nil, fmt.Errorf("tls: %w", err)
+			}
+			opt.SetTLSConfig(tlsConfig)
 		}
-		c.client = client
-	})
-	return c.connectErr
+	}
+	return opt, nil
+}
+
+func tryConnect(ctx context.Context, opt *options.ClientOptions) (*mongo.Client, error) {
+	// The client outlives the resource operation that first needed it,
+	// so only the ping is bound to the caller's context.
+	client, err := mongo.Connect(context.WithoutCancel(ctx), opt)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	if err := client.Ping(ctx, readpref.PrimaryPreferred()); err != nil {
+		client.Disconnect(context.WithoutCancel(ctx))
+		return nil, fmt.Errorf("ping: %w", wrapConnectError(err))
+	}
+	return client, nil
 }
 
 type CommandResponse struct {
@@ -91,6 +215,58 @@ type RoleSameDBRef string
 
 func (RoleSameDBRef) isRoleRef() {}
 
+// AuthenticationRestriction limits from where a user may authenticate.
+// A user may authenticate if all fields of any of its restrictions match.
+//
+// [https://www.mongodb.com/docs/manual/reference/command/createUser/#authentication-restrictions]
+type AuthenticationRestriction struct {
+	// ClientSource is the IP addresses or CIDR ranges the client must
+	// connect from.
+	ClientSource []string `bson:"clientSource,omitempty"`
+	// ServerAddress is the IP addresses or CIDR ranges of the server that
+	// the client must connect to.
+	ServerAddress []string `bson:"serverAddress,omitempty"`
+}
+
+// AuthenticationRestrictions is a list of authentication restrictions, as
+// returned by the usersInfo and rolesInfo commands.
+type AuthenticationRestrictions []AuthenticationRestriction
+
+// Ensure it implements the interface.
+var _ bson.ValueUnmarshaler = &AuthenticationRestrictions{}
+
+// UnmarshalBSONValue implements [bson.ValueUnmarshaler]. Depending on the
+// command and server version, the restrictions are either a list of
+// documents, or a list of lists of documents, which is flattened.
+func (r *AuthenticationRestrictions) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bson.TypeNull {
+		*r = nil
+		return nil
+	}
+	var elements []bson.RawValue
+	if err := (bson.RawValue{Type: t, Value: data}).Unmarshal(&elements); err != nil {
+		return err
+	}
+	var result AuthenticationRestrictions
+	for _, elem := range elements {
+		if elem.Type == bson.TypeArray {
+			var nested AuthenticationRestrictions
+			if err := nested.UnmarshalBSONValue(elem.Type, elem.Value); err != nil {
+				return err
+			}
+			result = append(result, nested...)
+			continue
+		}
+		var restriction AuthenticationRestriction
+		if err := elem.Unmarshal(&restriction); err != nil {
+			return err
+		}
+		result = append(result, restriction)
+	}
+	*r = result
+	return nil
+}
+
 // Mechanism is the mechanism for user authentication.
 //
 // [https://www.mongodb.com/docs/manual/reference/parameters/#mongodb-parameter-param.authenticationMechanisms]
@@ -102,6 +278,8 @@ var Mechanisms = []Mechanism{
 	MechanismMONGODBX509,
 	MechanismPLAIN,
 	MechanismGSSAPI,
+	MechanismMONGODBAWS,
+	MechanismMONGODBOIDC,
 }
 
 const (
@@ -143,8 +321,26 @@ const (
 	//
 	// [https://www.mongodb.com/docs/manual/core/authentication/#std-label-security-auth-kerberos]
 	MechanismGSSAPI Mechanism = "GSSAPI"
+
+	// MechanismMONGODBAWS is the mechanism for external authentication using
+	// AWS IAM credentials. The user is the ARN of the IAM user or role.
+	//
+	// [https://www.mongodb.com/docs/manual/core/security-aws/]
+	MechanismMONGODBAWS Mechanism = "MONGODB-AWS"
+
+	// MechanismMONGODBOIDC is the mechanism for external authentication using
+	// OpenID Connect. This mechanism is available only in MongoDB Enterprise 7.0 and later.
+	//
+	// [https://www.mongodb.com/docs/manual/core/security-oidc/]
+	MechanismMONGODBOIDC Mechanism = "MONGODB-OIDC"
 )
 
+// writeCmdOptions returns the options for commands that modify users or roles,
+// which must always be sent to the primary of the replica set.
+func writeCmdOptions() *options.RunCmdOptions {
+	return options.RunCmd().SetReadPreference(readpref.Primary())
+}
+
 func validateResponse(response CommandResponse) error {
 	if response.OK != 1 {
 		return fmt.Errorf("%w: ok=%d", ErrNotOK, response.OK)
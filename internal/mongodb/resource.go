@@ -32,12 +32,15 @@ func (r *ResourceWrapper) UnmarshalBSONValue(t bsontype.Type, data []byte) error
 		return err
 	}
 	if value, ok := m["system_buckets"]; ok {
-		if buckets, ok := value.(string); ok {
-			r.Union = ResourceSystemBuckets{SystemBuckets: buckets}
-			return nil
-		} else {
+		if _, ok := value.(string); !ok {
 			return fmt.Errorf("resource.system_buckets must be string, got %T", value)
 		}
+		var buckets ResourceSystemBuckets
+		if err := rv.Unmarshal(&buckets); err != nil {
+			return err
+		}
+		r.Union = buckets
+		return nil
 	}
 	if value, ok := m["anyResource"]; ok {
 		if anyResource, ok := value.(bool); ok {
@@ -86,7 +89,13 @@ type ResourceCollection struct {
 
 func (ResourceCollection) isResource() {}
 
+// ResourceSystemBuckets targets the internal "system.buckets.*" collections
+// that store the data of time series collections. An empty DB matches all
+// databases, and an empty SystemBuckets matches all time series collections.
+//
+// [https://www.mongodb.com/docs/manual/reference/resource-document/#time-series-collections]
 type ResourceSystemBuckets struct {
+	DB            string `bson:"db,omitempty"`
 	SystemBuckets string `bson:"system_buckets"`
 }
 
@@ -20,6 +20,8 @@ type Role struct {
 	Roles               []RoleDBRef `bson:"roles"`
 	InheritedRoles      []RoleDBRef `bson:"inheritedRoles"`
 	InheritedPrivileges []Privilege `bson:"inheritedPrivileges"`
+
+	AuthenticationRestrictions AuthenticationRestrictions `bson:"authenticationRestrictions"`
 }
 
 type Privilege struct {
@@ -55,9 +57,10 @@ func (c *Client) GetDBRole(ctx context.Context, dbName, roleName string) (Role,
 
 func (c *Client) runRolesInfoSingle(ctx context.Context, dbName, roleName string) (Role, error) {
 	query := rolesInfoCommand{
-		RolesInfo:        roleName,
-		ShowPrivileges:   true,
-		ShowBuiltinRoles: true,
+		RolesInfo:                      roleName,
+		ShowAuthenticationRestrictions: true,
+		ShowPrivileges:                 true,
+		ShowBuiltinRoles:               true,
 	}
 	roles, err := c.runRolesInfo(ctx, dbName, query)
 	if err != nil {
@@ -70,20 +73,10 @@ func (c *Client) runRolesInfoSingle(ctx context.Context, dbName, roleName string
 }
 
 func (c *Client) runRolesInfo(ctx context.Context, dbName string, query rolesInfoCommand) ([]Role, error) {
-	db := c.client.Database(dbName)
-
-	result := db.RunCommand(ctx, query)
-	if err := result.Err(); err != nil {
-		return nil, err
-	}
 	var response struct {
-		CommandResponse `bson:",inline"`
-		Roles           []Role `bson:"roles"`
-	}
-	if err := result.Decode(&response); err != nil {
-		return nil, err
+		Roles []Role `bson:"roles"`
 	}
-	if err := validateResponse(response.CommandResponse); err != nil {
+	if err := c.runCommand(ctx, dbName, "rolesInfo", query, &response); err != nil {
 		return nil, err
 	}
 	return response.Roles, nil
@@ -93,6 +86,8 @@ type NewRole struct {
 	Role       string      `bson:"createRole"`
 	Privileges []Privilege `bson:"privileges"`
 	Roles      []RoleRef   `bson:"roles"`
+
+	AuthenticationRestrictions []AuthenticationRestriction `bson:"authenticationRestrictions,omitempty"`
 }
 
 func (c *Client) CreateDBRole(ctx context.Context, dbName string, newRole NewRole) (Role, error) {
@@ -121,38 +116,50 @@ func (c *Client) CreateDBRole(ctx context.Context, dbName string, newRole NewRol
 }
 
 func (c *Client) runCreateRole(ctx context.Context, dbName string, newRole NewRole) error {
-	db := c.client.Database(dbName)
-	result := db.RunCommand(ctx, newRole)
-	if err := result.Err(); err != nil {
-		return err
-	}
-	var response CommandResponse
-	if err := result.Decode(&response); err != nil {
-		return err
-	}
-	if err := validateResponse(response); err != nil {
-		return err
-	}
-	return nil
+	return c.runCommand(ctx, dbName, "createRole", newRole, nil, writeCmdOptions())
 }
 
+// UpdateRole is the updateRole command. Only non-nil fields are sent,
+// where a pointer to an empty slice clears that field on the role.
 type UpdateRole struct {
-	Role       string      `bson:"updateRole"`
-	Privileges []Privilege `bson:"privileges,omitempty"`
-	Roles      []RoleRef   `bson:"roles,omitempty"`
+	Role       string       `bson:"updateRole"`
+	Privileges *[]Privilege `bson:"privileges,omitempty"`
+	Roles      *[]RoleRef   `bson:"roles,omitempty"`
+
+	AuthenticationRestrictions *[]AuthenticationRestriction `bson:"authenticationRestrictions,omitempty"`
+}
+
+func (u UpdateRole) hasChanges() bool {
+	return u.Privileges != nil || u.Roles != nil || u.AuthenticationRestrictions != nil
 }
 
 func (c *Client) UpdateDBRole(ctx context.Context, dbName string, update UpdateRole) (Role, error) {
 	if err := c.connect(ctx); err != nil {
 		return Role{}, err
 	}
-	for i := range update.Privileges {
-		if update.Privileges[i].Actions == nil {
-			update.Privileges[i].Actions = []string{}
+	// Make sure cleared fields are sent as empty values instead of null.
+	if update.Privileges != nil {
+		if *update.Privileges == nil {
+			update.Privileges = &[]Privilege{}
+		}
+		privileges := *update.Privileges
+		for i := range privileges {
+			if privileges[i].Actions == nil {
+				privileges[i].Actions = []string{}
+			}
 		}
 	}
-	if err := c.runUpdateRole(ctx, dbName, update); err != nil {
-		return Role{}, err
+	if update.Roles != nil && *update.Roles == nil {
+		update.Roles = &[]RoleRef{}
+	}
+	if update.AuthenticationRestrictions != nil && *update.AuthenticationRestrictions == nil {
+		update.AuthenticationRestrictions = &[]AuthenticationRestriction{}
+	}
+	// MongoDB rejects updateRole commands without any fields to update.
+	if update.hasChanges() {
+		if err := c.runUpdateRole(ctx, dbName, update); err != nil {
+			return Role{}, err
+		}
 	}
 	role, err := c.runRolesInfoSingle(ctx, dbName, update.Role)
 	if err != nil {
@@ -162,20 +169,7 @@ func (c *Client) UpdateDBRole(ctx context.Context, dbName string, update UpdateR
 }
 
 func (c *Client) runUpdateRole(ctx context.Context, dbName string, update UpdateRole) error {
-	db := c.client.Database(dbName)
-
-	result := db.RunCommand(ctx, update)
-	if err := result.Err(); err != nil {
-		return err
-	}
-	var response CommandResponse
-	if err := result.Decode(&response); err != nil {
-		return err
-	}
-	if err := validateResponse(response); err != nil {
-		return err
-	}
-	return nil
+	return c.runCommand(ctx, dbName, "updateRole", update, nil, writeCmdOptions())
 }
 
 func (c *Client) DeleteDBRole(ctx context.Context, dbName, roleName string) error {
@@ -186,17 +180,70 @@ func (c *Client) DeleteDBRole(ctx context.Context, dbName, roleName string) erro
 }
 
 func (c *Client) runDropRole(ctx context.Context, dbName, roleName string) error {
-	db := c.client.Database(dbName)
-
-	result := db.RunCommand(ctx, bson.D{
+	return c.runCommand(ctx, dbName, "dropRole", bson.D{
 		{Key: "dropRole", Value: roleName},
-	})
-	if err := result.Err(); err != nil {
+	}, nil, writeCmdOptions())
+}
+
+// GrantPrivilegesToRole adds privileges to the role, keeping the privileges
+// it already has. Actions on a resource that the role already has
+// privileges on are merged into the existing privilege.
+func (c *Client) GrantPrivilegesToRole(ctx context.Context, dbName, roleName string, privileges []Privilege) (Role, error) {
+	if err := c.connect(ctx); err != nil {
+		return Role{}, err
+	}
+	if err := c.runCommand(ctx, dbName, "grantPrivilegesToRole", bson.D{
+		{Key: "grantPrivilegesToRole", Value: roleName},
+		{Key: "privileges", Value: privileges},
+	}, nil, writeCmdOptions()); err != nil {
+		return Role{}, err
+	}
+	role, err := c.runRolesInfoSingle(ctx, dbName, roleName)
+	if err != nil {
+		return Role{}, fmt.Errorf("get updated role: %w", err)
+	}
+	return role, nil
+}
+
+// RevokePrivilegesFromRole removes the actions of the privileges from the
+// role, keeping any other actions on the same resources.
+func (c *Client) RevokePrivilegesFromRole(ctx context.Context, dbName, roleName string, privileges []Privilege) error {
+	if err := c.connect(ctx); err != nil {
 		return err
 	}
-	var response CommandResponse
-	if err := result.Decode(&response); err != nil {
+	return c.runCommand(ctx, dbName, "revokePrivilegesFromRole", bson.D{
+		{Key: "revokePrivilegesFromRole", Value: roleName},
+		{Key: "privileges", Value: privileges},
+	}, nil, writeCmdOptions())
+}
+
+// GrantRolesToRole makes the role inherit from the given roles, in addition
+// to the roles it already inherits from.
+func (c *Client) GrantRolesToRole(ctx context.Context, dbName, roleName string, roles []RoleRef) (Role, error) {
+	if err := c.connect(ctx); err != nil {
+		return Role{}, err
+	}
+	if err := c.runCommand(ctx, dbName, "grantRolesToRole", bson.D{
+		{Key: "grantRolesToRole", Value: roleName},
+		{Key: "roles", Value: roles},
+	}, nil, writeCmdOptions()); err != nil {
+		return Role{}, err
+	}
+	role, err := c.runRolesInfoSingle(ctx, dbName, roleName)
+	if err != nil {
+		return Role{}, fmt.Errorf("get updated role: %w", err)
+	}
+	return role, nil
+}
+
+// RevokeRolesFromRole stops the role from inheriting from the given roles,
+// keeping its other inherited roles.
+func (c *Client) RevokeRolesFromRole(ctx context.Context, dbName, roleName string, roles []RoleRef) error {
+	if err := c.connect(ctx); err != nil {
 		return err
 	}
-	return validateResponse(response)
+	return c.runCommand(ctx, dbName, "revokeRolesFromRole", bson.D{
+		{Key: "revokeRolesFromRole", Value: roleName},
+		{Key: "roles", Value: roles},
+	}, nil, writeCmdOptions())
 }
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// TLSConfig configures TLS using in-memory PEM encoded certificates,
+// instead of the file paths supported by the connection URI.
+type TLSConfig struct {
+	// Enabled toggles TLS on or off, overriding the "tls" option in the
+	// connection URI.
+	Enabled bool
+	// CAPEM is the PEM encoded certificate authorities used to verify the
+	// server certificate. The system's certificate pool is used when empty.
+	CAPEM string
+	// CertPEM and KeyPEM is the PEM encoded client certificate and private key
+	// sent to the server.
+	CertPEM string
+	KeyPEM  string
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+	// ServerName overrides the hostname used to verify the server certificate.
+	ServerName string
+}
+
+// Validate returns an error if the PEM encoded certificates or key are invalid.
+func (t TLSConfig) Validate() error {
+	_, err := t.build(nil)
+	return err
+}
+
+// build returns a [tls.Config] based on the base config, which may be nil,
+// such as the one created from the TLS options in the connection URI.
+func (t TLSConfig) build(base *tls.Config) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if base != nil {
+		config = base.Clone()
+	}
+	if t.CAPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(t.CAPEM)) {
+			return nil, errors.New("parse CA PEM: no certificates found")
+		}
+		config.RootCAs = pool
+	}
+	if t.CertPEM != "" || t.KeyPEM != "" {
+		cert, err := tls.X509KeyPair([]byte(t.CertPEM), []byte(t.KeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("parse client certificate and key PEM: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if t.InsecureSkipVerify {
+		config.InsecureSkipVerify = true
+	}
+	if t.ServerName != "" {
+		config.ServerName = t.ServerName
+	}
+	return config, nil
+}
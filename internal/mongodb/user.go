@@ -6,10 +6,13 @@ package mongodb
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type User struct {
@@ -20,6 +23,8 @@ type User struct {
 	CustomData map[string]string `bson:"customData"`
 	Roles      []RoleDBRef       `bson:"roles"`
 	Mechanisms []Mechanism       `bson:"mechanisms"`
+	// AuthenticationRestrictions are only returned by [Client.GetDBUser].
+	AuthenticationRestrictions AuthenticationRestrictions `bson:"authenticationRestrictions"`
 }
 
 func (c *Client) ListDBUsers(ctx context.Context, dbName string, filter any) ([]User, error) {
@@ -55,7 +60,8 @@ func (c *Client) GetDBUser(ctx context.Context, dbName, userName string) (User,
 
 func (c *Client) runUsersInfoSingle(ctx context.Context, dbName, userName string) (User, error) {
 	query := usersInfoCommand{
-		UsersInfo: userName,
+		UsersInfo:                      userName,
+		ShowAuthenticationRestrictions: true,
 	}
 	users, err := c.runUsersInfo(ctx, dbName, query)
 	if err != nil {
@@ -70,34 +76,92 @@ func (c *Client) runUsersInfoSingle(ctx context.Context, dbName, userName string
 type usersInfoCommand struct {
 	UsersInfo any `bson:"usersInfo"`
 	Filter    any `bson:"filter,omitempty"`
+	// ShowAuthenticationRestrictions cannot be combined with forAllDBs.
+	ShowAuthenticationRestrictions bool `bson:"showAuthenticationRestrictions,omitempty"`
 }
 
 func (c *Client) runUsersInfo(ctx context.Context, dbName string, query usersInfoCommand) ([]User, error) {
-	db := c.client.Database(dbName)
-
-	result := db.RunCommand(ctx, query)
-	if err := result.Err(); err != nil {
-		return nil, err
-	}
 	var response struct {
-		CommandResponse `bson:",inline"`
-		Users           []User `bson:"users"`
-	}
-	if err := result.Decode(&response); err != nil {
-		return nil, err
+		Users []User `bson:"users"`
 	}
-	if err := validateResponse(response.CommandResponse); err != nil {
+	if err := c.runCommand(ctx, dbName, "usersInfo", query, &response); err != nil {
 		return nil, err
 	}
 	return response.Users, nil
 }
 
+// PasswordDigest controls where the password is digested before MongoDB
+// derives the SCRAM credentials from it.
+type PasswordDigest int
+
+const (
+	// DigestServer sends the plaintext password, which is digested by the
+	// server. This is the default, and supports all SCRAM mechanisms.
+	DigestServer PasswordDigest = iota
+	// DigestClient digests the password using [DigestPassword] on the client,
+	// so the plaintext password is never sent to the server. The server only
+	// accepts digested passwords for users with only the [MechanismSCRAMSHA1]
+	// mechanism, as the SCRAM-SHA-256 credentials are derived from the
+	// plaintext password.
+	DigestClient
+	// DigestPrecomputed sends the password as is, as it was already digested
+	// using [DigestPassword]. The same restrictions as for [DigestClient] apply.
+	DigestPrecomputed
+)
+
+// DigestPassword returns the digest of the password, which the server
+// derives the SCRAM-SHA-1 credentials from. The digest depends on the
+// username, and is equivalent to the password for SCRAM-SHA-1 authentication.
+//
+// [https://github.com/mongodb/specifications/blob/master/source/auth/auth.md#scram-sha-1]
+func DigestPassword(userName, password string) string {
+	sum := md5.Sum([]byte(userName + ":mongo:" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkDigestMechanisms returns an error wrapping [ErrBadValue] if the
+// password is digested on the client, but the mechanisms are not only
+// [MechanismSCRAMSHA1]. When the mechanisms are unset, the server defaults
+// to also include [MechanismSCRAMSHA256].
+//
+// MongoDB derives SCRAM-SHA-256 credentials from the plaintext password,
+// and no user management command accepts precomputed SCRAM credentials,
+// so there is no way to create SCRAM-SHA-256 users without sending the
+// plaintext password.
+func checkDigestMechanisms(digest PasswordDigest, mechanisms []Mechanism) error {
+	if digest == DigestServer {
+		return nil
+	}
+	if len(mechanisms) != 1 || mechanisms[0] != MechanismSCRAMSHA1 {
+		return fmt.Errorf("%w: passwords digested on the client require the mechanisms to only be %s, "+
+			"as %s credentials are derived from the plaintext password", ErrBadValue, MechanismSCRAMSHA1, MechanismSCRAMSHA256)
+	}
+	return nil
+}
+
+// digestPasswordOption returns the value of the digestPassword option for
+// the createUser and updateUser commands, and the password to send.
+func digestPasswordOption(userName, password string, digest PasswordDigest) (bool, string) {
+	switch digest {
+	case DigestClient:
+		return false, DigestPassword(userName, password)
+	case DigestPrecomputed:
+		return false, password
+	default:
+		return true, password
+	}
+}
+
 type NewUser struct {
 	User       string            `bson:"createUser"`
-	Password   string            `bson:"pwd"`
+	Password   string            `bson:"pwd,omitempty"` // must be empty for users in [DBExternal]
 	CustomData map[string]string `bson:"customData,omitempty"`
 	Roles      []RoleRef         `bson:"roles"`
 	Mechanisms []Mechanism       `bson:"mechanisms,omitempty"`
+	// Digest controls where the password is digested.
+	Digest PasswordDigest `bson:"-"`
+
+	AuthenticationRestrictions []AuthenticationRestriction `bson:"authenticationRestrictions,omitempty"`
 }
 
 func (c *Client) CreateDBUser(ctx context.Context, dbName string, newUser NewUser) (User, error) {
@@ -115,8 +179,6 @@ func (c *Client) CreateDBUser(ctx context.Context, dbName string, newUser NewUse
 }
 
 func (c *Client) runCreateUser(ctx context.Context, dbName string, newUser NewUser) error {
-	db := c.client.Database(dbName)
-
 	// Empty list is never a valid value, and the MongoDB BSON encoder doesn't
 	// seem to fully treat empty slices as empty, even though the docs say it should.
 	if len(newUser.Mechanisms) == 0 {
@@ -128,39 +190,63 @@ func (c *Client) runCreateUser(ctx context.Context, dbName string, newUser NewUs
 
 	var cmd = struct {
 		NewUser        `bson:",inline"`
-		DigestPassword bool `bson:"digestPassword"`
+		DigestPassword *bool `bson:"digestPassword,omitempty"`
 	}{
-		NewUser:        newUser,
-		DigestPassword: true,
+		NewUser: newUser,
 	}
-	result := db.RunCommand(ctx, cmd)
-	if err := result.Err(); err != nil {
-		return err
-	}
-	var response CommandResponse
-	if err := result.Decode(&response); err != nil {
-		return err
-	}
-	if err := validateResponse(response); err != nil {
-		return err
+	if newUser.Password != "" {
+		if err := checkDigestMechanisms(newUser.Digest, newUser.Mechanisms); err != nil {
+			return err
+		}
+		digestPassword, pwd := digestPasswordOption(newUser.User, newUser.Password, newUser.Digest)
+		cmd.Password = pwd
+		cmd.DigestPassword = &digestPassword
 	}
-	return nil
+	return c.runCommand(ctx, dbName, "createUser", cmd, nil, writeCmdOptions())
 }
 
+// UpdateUser is the updateUser command. Only non-nil fields are sent,
+// where a pointer to an empty value clears that field on the user.
 type UpdateUser struct {
-	User       string            `bson:"updateUser"`
-	Password   string            `bson:"pwd,omitempty"`
-	CustomData map[string]string `bson:"customData,omitempty"`
-	Roles      []RoleRef         `bson:"roles,omitempty"`
-	Mechanisms []Mechanism       `bson:"mechanisms,omitempty"`
+	User       string             `bson:"updateUser"`
+	Password   *string            `bson:"pwd,omitempty"`
+	CustomData *map[string]string `bson:"customData,omitempty"`
+	Roles      *[]RoleRef         `bson:"roles,omitempty"`
+	Mechanisms *[]Mechanism       `bson:"mechanisms,omitempty"`
+	// Digest controls where the password is digested.
+	Digest PasswordDigest `bson:"-"`
+
+	AuthenticationRestrictions *[]AuthenticationRestriction `bson:"authenticationRestrictions,omitempty"`
+}
+
+func (u UpdateUser) hasChanges() bool {
+	return u.Password != nil || u.CustomData != nil || u.Roles != nil || u.Mechanisms != nil ||
+		u.AuthenticationRestrictions != nil
 }
 
 func (c *Client) UpdateDBUser(ctx context.Context, dbName string, update UpdateUser) (User, error) {
 	if err := c.connect(ctx); err != nil {
 		return User{}, err
 	}
-	if err := c.runUpdateUser(ctx, dbName, update); err != nil {
-		return User{}, err
+	// Make sure cleared fields are sent as empty values instead of null.
+	if update.CustomData != nil && *update.CustomData == nil {
+		update.CustomData = &map[string]string{}
+	}
+	if update.Roles != nil && *update.Roles == nil {
+		update.Roles = &[]RoleRef{}
+	}
+	if update.AuthenticationRestrictions != nil && *update.AuthenticationRestrictions == nil {
+		update.AuthenticationRestrictions = &[]AuthenticationRestriction{}
+	}
+	// Empty list is never a valid value, so treat it as unchanged.
+	if update.Mechanisms != nil && len(*update.Mechanisms) == 0 {
+		update.Mechanisms = nil
+	}
+	// MongoDB rejects updateUser commands without any fields to update.
+	if update.hasChanges() {
+		if err := c.runUpdateUser(ctx, dbName, update); err != nil {
+			return User{}, err
+		}
 	}
 	user, err := c.runUsersInfoSingle(ctx, dbName, update.User)
 	if err != nil {
@@ -170,20 +256,25 @@ func (c *Client) UpdateDBUser(ctx context.Context, dbName string, update UpdateU
 }
 
 func (c *Client) runUpdateUser(ctx context.Context, dbName string, update UpdateUser) error {
-	db := c.client.Database(dbName)
-
-	result := db.RunCommand(ctx, update)
-	if err := result.Err(); err != nil {
-		return err
-	}
-	var response CommandResponse
-	if err := result.Decode(&response); err != nil {
-		return err
+	var cmd = struct {
+		UpdateUser     `bson:",inline"`
+		DigestPassword *bool `bson:"digestPassword,omitempty"`
+	}{
+		UpdateUser: update,
 	}
-	if err := validateResponse(response); err != nil {
-		return err
+	if update.Password != nil {
+		// The mechanisms of the existing user are checked by the server,
+		// which rejects digested passwords for SCRAM-SHA-256 users.
+		if update.Mechanisms != nil {
+			if err := checkDigestMechanisms(update.Digest, *update.Mechanisms); err != nil {
+				return err
+			}
+		}
+		digestPassword, pwd := digestPasswordOption(update.User, *update.Password, update.Digest)
+		cmd.Password = &pwd
+		cmd.DigestPassword = &digestPassword
 	}
-	return nil
+	return c.runCommand(ctx, dbName, "updateUser", cmd, nil, writeCmdOptions())
 }
 
 func (c *Client) DeleteDBUser(ctx context.Context, dbName, userName string) error {
@@ -194,17 +285,65 @@ func (c *Client) DeleteDBUser(ctx context.Context, dbName, userName string) erro
 }
 
 func (c *Client) runDropUser(ctx context.Context, dbName, userName string) error {
-	db := c.client.Database(dbName)
-
-	result := db.RunCommand(ctx, bson.D{
+	return c.runCommand(ctx, dbName, "dropUser", bson.D{
 		{Key: "dropUser", Value: userName},
-	})
-	if err := result.Err(); err != nil {
+	}, nil, writeCmdOptions())
+}
+
+// GrantRolesToUser adds roles to the user, keeping the roles it already has.
+func (c *Client) GrantRolesToUser(ctx context.Context, dbName, userName string, roles []RoleRef) (User, error) {
+	if err := c.connect(ctx); err != nil {
+		return User{}, err
+	}
+	if err := c.runCommand(ctx, dbName, "grantRolesToUser", bson.D{
+		{Key: "grantRolesToUser", Value: userName},
+		{Key: "roles", Value: roles},
+	}, nil, writeCmdOptions()); err != nil {
+		return User{}, err
+	}
+	user, err := c.runUsersInfoSingle(ctx, dbName, userName)
+	if err != nil {
+		return User{}, fmt.Errorf("get updated user: %w", err)
+	}
+	return user, nil
+}
+
+// RevokeRolesFromUser removes roles from the user, keeping its other roles.
+// Revoking a role the user does not have is a no-op.
+func (c *Client) RevokeRolesFromUser(ctx context.Context, dbName, userName string, roles []RoleRef) error {
+	if err := c.connect(ctx); err != nil {
 		return err
 	}
-	var response CommandResponse
-	if err := result.Decode(&response); err != nil {
+	return c.runCommand(ctx, dbName, "revokeRolesFromUser", bson.D{
+		{Key: "revokeRolesFromUser", Value: userName},
+		{Key: "roles", Value: roles},
+	}, nil, writeCmdOptions())
+}
+
+// VerifyPassword checks if the password is valid for the user, by doing a
+// SCRAM authentication handshake as the user on a separate, short-lived
+// connection. The mechanism is negotiated with the server.
+//
+// Returns an error wrapping [ErrAuthenticationFailed] if the server rejected
+// the password. Other errors, such as network errors, are returned as is.
+func (c *Client) VerifyPassword(ctx context.Context, dbName, userName, password string) error {
+	opt, err := c.clientOptions()
+	if err != nil {
+		return err
+	}
+	// Replace the credentials from the URI and provider configuration,
+	// such as an X.509 mechanism, to only authenticate as the user.
+	opt.SetAuth(options.Credential{
+		AuthSource:  dbName,
+		Username:    userName,
+		Password:    password,
+		PasswordSet: true,
+	})
+	opt.SetMaxPoolSize(1)
+	client, err := tryConnect(ctx, opt)
+	if err != nil {
 		return err
 	}
-	return validateResponse(response)
+	client.Disconnect(context.WithoutCancel(ctx))
+	return nil
 }
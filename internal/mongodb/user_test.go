@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDigestPassword(t *testing.T) {
+	// Example from the SCRAM-SHA-1 section of the MongoDB auth specification.
+	want := "1c33006ec1ffd90f9cadcbcc0e118200"
+	if got := DigestPassword("user", "pencil"); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestCheckDigestMechanisms(t *testing.T) {
+	tests := []struct {
+		name       string
+		digest     PasswordDigest
+		mechanisms []Mechanism
+		wantErr    bool
+	}{
+		{name: "server digest with defaults", digest: DigestServer, mechanisms: nil, wantErr: false},
+		{name: "server digest with SCRAM-SHA-256", digest: DigestServer, mechanisms: []Mechanism{MechanismSCRAMSHA256}, wantErr: false},
+		{name: "client digest with SCRAM-SHA-1", digest: DigestClient, mechanisms: []Mechanism{MechanismSCRAMSHA1}, wantErr: false},
+		{name: "precomputed digest with SCRAM-SHA-1", digest: DigestPrecomputed, mechanisms: []Mechanism{MechanismSCRAMSHA1}, wantErr: false},
+		{name: "client digest with defaults", digest: DigestClient, mechanisms: nil, wantErr: true},
+		{name: "client digest with SCRAM-SHA-256", digest: DigestClient, mechanisms: []Mechanism{MechanismSCRAMSHA256}, wantErr: true},
+		{
+			name:       "precomputed digest with both",
+			digest:     DigestPrecomputed,
+			mechanisms: []Mechanism{MechanismSCRAMSHA1, MechanismSCRAMSHA256},
+			wantErr:    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDigestMechanisms(tc.digest, tc.mechanisms)
+			if tc.wantErr && !errors.Is(err, ErrBadValue) {
+				t.Errorf("want error matching ErrBadValue, got %v", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("want no error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var authenticationRestrictionResourceAttributeSchema = schema.SetNestedAttribute{
+	Optional: true,
+	MarkdownDescription: "Restrictions on where the client may authenticate from. " +
+		"Authentication is allowed if all the attributes of any of the restrictions match.\n" +
+		"  See: <https://www.mongodb.com/docs/manual/reference/command/createUser/#authentication-restrictions>",
+	NestedObject: schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"client_source": schema.SetAttribute{
+				Optional: true,
+				MarkdownDescription: "IP addresses or CIDR ranges that the client must connect from, " +
+					"such as `10.0.0.0/16`.",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(cidrValidator{}),
+					setvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("server_address")),
+				},
+			},
+			"server_address": schema.SetAttribute{
+				Optional: true,
+				MarkdownDescription: "IP addresses or CIDR ranges of the server that the client must connect to, " +
+					"such as `10.1.0.0/24`.",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(cidrValidator{}),
+				},
+			},
+		},
+	},
+}
+
+type AuthenticationRestrictionResourceModel struct {
+	ClientSource  []types.String `tfsdk:"client_source"`
+	ServerAddress []types.String `tfsdk:"server_address"`
+}
+
+func (r AuthenticationRestrictionResourceModel) toAuthenticationRestriction() mongodb.AuthenticationRestriction {
+	return mongodb.AuthenticationRestriction{
+		ClientSource:  fromTypesStringSliceOrNil(r.ClientSource),
+		ServerAddress: fromTypesStringSliceOrNil(r.ServerAddress),
+	}
+}
+
+// Equal returns true if both restrictions contain the same addresses.
+func (r AuthenticationRestrictionResourceModel) Equal(other AuthenticationRestrictionResourceModel) bool {
+	return equalUnordered(r.ClientSource, other.ClientSource) &&
+		equalUnordered(r.ServerAddress, other.ServerAddress)
+}
+
+func fromTypesAuthenticationRestrictionResourceSlice(restrictions []AuthenticationRestrictionResourceModel) []mongodb.AuthenticationRestriction {
+	if restrictions == nil {
+		return nil
+	}
+	result := make([]mongodb.AuthenticationRestriction, len(restrictions))
+	for i, restriction := range restrictions {
+		result[i] = restriction.toAuthenticationRestriction()
+	}
+	return result
+}
+
+func toTypesAuthenticationRestrictionResourceSlice(restrictions []mongodb.AuthenticationRestriction) []AuthenticationRestrictionResourceModel {
+	result := make([]AuthenticationRestrictionResourceModel, len(restrictions))
+	for i, restriction := range restrictions {
+		result[i] = AuthenticationRestrictionResourceModel{
+			ClientSource:  toTypesStringSliceOrNil(restriction.ClientSource),
+			ServerAddress: toTypesStringSliceOrNil(restriction.ServerAddress),
+		}
+	}
+	return result
+}
+
+// toTypesStringSliceOrNil is like [toTypesStringSlice], but returns nil for
+// an empty slice, which is a null value in Terraform.
+func toTypesStringSliceOrNil(slice []string) []types.String {
+	if len(slice) == 0 {
+		return nil
+	}
+	return toTypesStringSlice(slice)
+}
+
+// fromTypesStringSliceOrNil is like [fromTypesStringSlice], but returns nil
+// for a null value, so the field is omitted.
+func fromTypesStringSliceOrNil(slice []types.String) []string {
+	if slice == nil {
+		return nil
+	}
+	return fromTypesStringSlice[string](slice)
+}
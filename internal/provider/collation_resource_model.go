@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// collationLocaleSimple is the locale for simple binary comparison.
+const collationLocaleSimple = "simple"
+
+var collationResourceAttributesSchema = map[string]schema.Attribute{
+	"locale": schema.StringAttribute{
+		Required: true,
+		MarkdownDescription: "ICU locale, such as `en` or `de@collation=phonebook`, or `simple` for binary comparison.\n" +
+			"  See: <https://www.mongodb.com/docs/manual/reference/collation-locales-defaults/>",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	},
+	"case_level": schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Whether to include case comparison at strength level 1 or 2.",
+	},
+	"case_first": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Sort order of case differences during tertiary level comparisons.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("upper", "lower", "off"),
+		},
+	},
+	"strength": schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Level of comparison to perform, from `1` (base characters only) to `5` (identical).",
+		Validators: []validator.Int64{
+			int64validator.Between(1, 5),
+		},
+	},
+	"numeric_ordering": schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Whether to compare numeric strings as numbers instead of as strings.",
+	},
+	"alternate": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Whether whitespace and punctuation are considered as base characters.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("non-ignorable", "shifted"),
+		},
+	},
+	"max_variable": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Which characters are ignorable when `alternate` is `shifted`.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("punct", "space"),
+		},
+	},
+	"normalization": schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Whether to check if text requires normalization and to perform normalization.",
+	},
+	"backwards": schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Whether strings with diacritics sort from the back of the string.",
+	},
+}
+
+type CollationResourceModel struct {
+	Locale          types.String `tfsdk:"locale"`
+	CaseLevel       types.Bool   `tfsdk:"case_level"`
+	CaseFirst       types.String `tfsdk:"case_first"`
+	Strength        types.Int64  `tfsdk:"strength"`
+	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
+	Alternate       types.String `tfsdk:"alternate"`
+	MaxVariable     types.String `tfsdk:"max_variable"`
+	Normalization   types.Bool   `tfsdk:"normalization"`
+	Backwards       types.Bool   `tfsdk:"backwards"`
+}
+
+func (c *CollationResourceModel) toCollation() *mongodb.Collation {
+	if c == nil {
+		return nil
+	}
+	return &mongodb.Collation{
+		Locale:          c.Locale.ValueString(),
+		CaseLevel:       c.CaseLevel.ValueBoolPointer(),
+		CaseFirst:       c.CaseFirst.ValueString(),
+		Strength:        int(c.Strength.ValueInt64()),
+		NumericOrdering: c.NumericOrdering.ValueBoolPointer(),
+		Alternate:       c.Alternate.ValueString(),
+		MaxVariable:     c.MaxVariable.ValueString(),
+		Normalization:   c.Normalization.ValueBoolPointer(),
+		Backwards:       c.Backwards.ValueBoolPointer(),
+	}
+}
+
+// toTypesCollationResource converts the collation returned by MongoDB.
+// MongoDB fills in defaults for all unset fields, so only the fields that
+// are set in the old model are kept, to not report the defaults as changes.
+func toTypesCollationResource(old *CollationResourceModel, collation *mongodb.Collation) *CollationResourceModel {
+	if collation == nil {
+		// MongoDB does not store the "simple" collation, as it is the default,
+		// so keep it to not replace the resource on every apply.
+		if old != nil && old.Locale.ValueString() == collationLocaleSimple {
+			return old
+		}
+		return nil
+	}
+	result := &CollationResourceModel{
+		Locale:          types.StringValue(collation.Locale),
+		CaseLevel:       types.BoolNull(),
+		CaseFirst:       types.StringNull(),
+		Strength:        types.Int64Null(),
+		NumericOrdering: types.BoolNull(),
+		Alternate:       types.StringNull(),
+		MaxVariable:     types.StringNull(),
+		Normalization:   types.BoolNull(),
+		Backwards:       types.BoolNull(),
+	}
+	if old == nil {
+		return result
+	}
+	if !old.CaseLevel.IsNull() {
+		result.CaseLevel = types.BoolPointerValue(collation.CaseLevel)
+	}
+	if !old.CaseFirst.IsNull() {
+		result.CaseFirst = types.StringValue(collation.CaseFirst)
+	}
+	if !old.Strength.IsNull() {
+		result.Strength = types.Int64Value(int64(collation.Strength))
+	}
+	if !old.NumericOrdering.IsNull() {
+		result.NumericOrdering = types.BoolPointerValue(collation.NumericOrdering)
+	}
+	if !old.Alternate.IsNull() {
+		result.Alternate = types.StringValue(collation.Alternate)
+	}
+	if !old.MaxVariable.IsNull() {
+		result.MaxVariable = types.StringValue(collation.MaxVariable)
+	}
+	if !old.Normalization.IsNull() {
+		result.Normalization = types.BoolPointerValue(collation.Normalization)
+	}
+	if !old.Backwards.IsNull() {
+		result.Backwards = types.BoolPointerValue(collation.Backwards)
+	}
+	return result
+}
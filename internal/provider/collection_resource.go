@@ -0,0 +1,511 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CollectionResource{}
+var _ resource.ResourceWithConfigure = &CollectionResource{}
+var _ resource.ResourceWithImportState = &CollectionResource{}
+var _ resource.ResourceWithValidateConfig = &CollectionResource{}
+
+func NewCollectionResource() resource.Resource {
+	return &CollectionResource{}
+}
+
+// CollectionResource defines the resource implementation.
+type CollectionResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// CollectionResourceModel describes the resource data model.
+type CollectionResourceModel struct {
+	ID                           types.String                 `tfsdk:"id"`
+	DB                           types.String                 `tfsdk:"db"`
+	Name                         types.String                 `tfsdk:"name"`
+	Capped                       types.Bool                   `tfsdk:"capped"`
+	Size                         types.Int64                  `tfsdk:"size"`
+	Max                          types.Int64                  `tfsdk:"max"`
+	Collation                    *CollationResourceModel      `tfsdk:"collation"`
+	ClusteredIndex               *ClusteredIndexResourceModel `tfsdk:"clustered_index"`
+	ExpireAfterSeconds           types.Int64                  `tfsdk:"expire_after_seconds"`
+	ChangeStreamPreAndPostImages types.Bool                   `tfsdk:"change_stream_pre_and_post_images"`
+	WiredTigerConfigString       types.String                 `tfsdk:"wired_tiger_config_string"`
+	Timeouts                     timeouts.Value               `tfsdk:"timeouts"`
+}
+
+type ClusteredIndexResourceModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+// collectionAndDB returns the collection and database names, either from
+// the attributes or from an imported ID in the format "db.collection".
+func collectionAndDB(id, db, name types.String) (string, string, error) {
+	if !name.IsNull() && !db.IsNull() {
+		collName := name.ValueString()
+		dbName := db.ValueString()
+		if collName != "" && dbName != "" {
+			return collName, dbName, nil
+		}
+	}
+	if id.IsNull() || id.ValueString() == "" {
+		return "", "", errors.New("missing collection ID")
+	}
+	// Database names cannot contain dots, but collection names can.
+	dbName, collName, ok := strings.Cut(id.ValueString(), ".")
+	if !ok {
+		return "", "", errors.New("malformed collection ID, missing dot separator on db and collection")
+	}
+	if dbName == "" {
+		return "", "", errors.New("malformed collection ID, missing db")
+	}
+	if collName == "" {
+		return "", "", errors.New("malformed collection ID, missing collection")
+	}
+	return collName, dbName, nil
+}
+
+func (c CollectionResourceModel) collectionAndDB() (string, string, error) {
+	return collectionAndDB(c.ID, c.DB, c.Name)
+}
+
+func (c CollectionResourceModel) toNewCollection(collName string) mongodb.NewCollection {
+	newColl := mongodb.NewCollection{
+		Name: collName,
+		CollectionOptions: mongodb.CollectionOptions{
+			Capped:             c.Capped.ValueBool(),
+			Size:               c.Size.ValueInt64(),
+			Max:                c.Max.ValueInt64(),
+			Collation:          c.Collation.toCollation(),
+			ExpireAfterSeconds: c.ExpireAfterSeconds.ValueInt64Pointer(),
+		},
+	}
+	if c.ClusteredIndex != nil {
+		newColl.ClusteredIndex = mongodb.NewClusteredIndex(c.ClusteredIndex.Name.ValueString())
+	}
+	if c.ChangeStreamPreAndPostImages.ValueBool() {
+		newColl.ChangeStreamPreAndPostImages = &mongodb.ChangeStreamPreAndPostImages{Enabled: true}
+	}
+	if !c.WiredTigerConfigString.IsNull() {
+		newColl.StorageEngine = &mongodb.StorageEngine{
+			WiredTiger: &mongodb.WiredTigerConfig{ConfigString: c.WiredTigerConfigString.ValueString()},
+		}
+	}
+	return newColl
+}
+
+// toUpdateCollection returns the collMod command that changes the collection
+// from its prior state to the planned state. Only the changed fields are
+// included. All other attributes require replacing the collection.
+func (c CollectionResourceModel) toUpdateCollection(collName string, state CollectionResourceModel) mongodb.UpdateCollection {
+	update := mongodb.UpdateCollection{Name: collName}
+	if !c.ExpireAfterSeconds.Equal(state.ExpireAfterSeconds) {
+		if c.ExpireAfterSeconds.IsNull() {
+			update.ExpireAfterSeconds = mongodb.ExpireAfterSecondsOff
+		} else {
+			update.ExpireAfterSeconds = c.ExpireAfterSeconds.ValueInt64()
+		}
+	}
+	if !c.ChangeStreamPreAndPostImages.Equal(state.ChangeStreamPreAndPostImages) {
+		update.ChangeStreamPreAndPostImages = &mongodb.ChangeStreamPreAndPostImages{
+			Enabled: c.ChangeStreamPreAndPostImages.ValueBool(),
+		}
+	}
+	if !c.Size.Equal(state.Size) {
+		size := c.Size.ValueInt64()
+		update.CappedSize = &size
+	}
+	if !c.Max.Equal(state.Max) {
+		// Zero removes the limit on the number of documents.
+		max := c.Max.ValueInt64()
+		update.CappedMax = &max
+	}
+	return update
+}
+
+func (c *CollectionResourceModel) applyCollection(dbName string, coll mongodb.Collection) {
+	opts := coll.Options
+	c.ID = types.StringValue(dbName + "." + coll.Name)
+	c.DB = types.StringValue(dbName)
+	c.Name = types.StringValue(coll.Name)
+	if opts.Capped || !c.Capped.IsNull() {
+		c.Capped = types.BoolValue(opts.Capped)
+	}
+	// MongoDB rounds the size up to a multiple of 256 bytes.
+	if opts.Size == 0 {
+		c.Size = types.Int64Null()
+	} else if c.Size.IsNull() || roundCappedSize(c.Size.ValueInt64()) != opts.Size {
+		c.Size = types.Int64Value(opts.Size)
+	}
+	c.Max = types.Int64Null()
+	if opts.Max > 0 {
+		c.Max = types.Int64Value(opts.Max)
+	}
+	c.Collation = toTypesCollationResource(c.Collation, opts.Collation)
+	c.ClusteredIndex = toTypesClusteredIndexResource(c.ClusteredIndex, opts.ClusteredIndex)
+	c.ExpireAfterSeconds = types.Int64PointerValue(opts.ExpireAfterSeconds)
+	c.ChangeStreamPreAndPostImages = types.BoolValue(opts.ChangeStreamPreAndPostImages != nil && opts.ChangeStreamPreAndPostImages.Enabled)
+	c.WiredTigerConfigString = types.StringNull()
+	if opts.StorageEngine != nil && opts.StorageEngine.WiredTiger != nil && opts.StorageEngine.WiredTiger.ConfigString != "" {
+		c.WiredTigerConfigString = types.StringValue(opts.StorageEngine.WiredTiger.ConfigString)
+	}
+}
+
+func roundCappedSize(size int64) int64 {
+	return (size + 0xff) &^ 0xff
+}
+
+// toTypesClusteredIndexResource converts the clustered index returned by
+// MongoDB. The index name is only kept if it is set in the old model, as
+// MongoDB otherwise generates one.
+func toTypesClusteredIndexResource(old *ClusteredIndexResourceModel, index *mongodb.ClusteredIndex) *ClusteredIndexResourceModel {
+	if index == nil {
+		return nil
+	}
+	result := &ClusteredIndexResourceModel{Name: types.StringNull()}
+	if old != nil && !old.Name.IsNull() {
+		result.Name = types.StringValue(index.Name)
+	}
+	return result
+}
+
+func (r *CollectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Collection resource.\n\n" +
+			"Options that can be changed using the `collMod` command are updated in place. " +
+			"Changing any other option replaces the collection, which **permanently deletes all of its data**.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Collection unique ID in MongoDB. Is composed from the `db` and `name` fields.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"db": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Database this collection belongs to.\n\n" +
+					// Indenting here because the documentation generation doesn't do it
+					"  MongoDB has some restrictions on database names. Such as:\n\n" +
+					"  - Cannot contain any of the following characters (we're following Windows limits): `/\\. \"$*<>:|?`\n" +
+					"  - Cannot be empty.\n" +
+					"  - Cannot be longer than 64 characters.\n\n" +
+					"  See documentation:\n\n" +
+					"  - <https://www.mongodb.com/docs/v6.0/reference/limits/#naming-restrictions>",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: databaseValidators,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the collection.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: collectionValidators,
+			},
+			"capped": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Create a capped collection, which has a fixed maximum size and overwrites its oldest documents when full. " +
+					"Requires the `size` attribute.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Maximum size in bytes of a capped collection. MongoDB rounds the size up to a multiple of 256. " +
+					"Can be changed in place on MongoDB 6.0 and later.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("capped")),
+				},
+			},
+			"max": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Maximum number of documents in a capped collection. " +
+					"Can be changed in place on MongoDB 6.0 and later.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("capped")),
+				},
+			},
+			"collation": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Default collation of the collection, used by its indexes and queries.\n" +
+					"  See: <https://www.mongodb.com/docs/manual/reference/collation/>",
+				Attributes: collationResourceAttributesSchema,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"clustered_index": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Create a clustered collection, which stores its documents ordered by the `_id` field.\n" +
+					"  See: <https://www.mongodb.com/docs/manual/core/clustered-collections/>",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the clustered index. Defaults to a name generated by MongoDB.",
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Automatically delete documents from a clustered collection after this many seconds, " +
+					"based on the time in their `_id` field. Requires the `clustered_index` attribute.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+					int64validator.AlsoRequires(path.MatchRoot("clustered_index")),
+				},
+			},
+			"change_stream_pre_and_post_images": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Include the documents before and after each change in change stream events. " +
+					"Defaults to `false`.",
+			},
+			"wired_tiger_config_string": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "WiredTiger storage engine configuration for the collection, " +
+					"such as `block_compressor=zstd`.\n" +
+					"  See: <https://source.wiredtiger.com/mongodb-6.0/struct_w_t___s_e_s_s_i_o_n.html>",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *CollectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Only reading the validated attributes, as the nested objects may be
+	// unknown, which cannot be read into the model.
+	var capped types.Bool
+	var size types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("capped"), &capped)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("size"), &size)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if capped.ValueBool() && size.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("size"),
+			"Missing Attribute Configuration",
+			"The size attribute must be set for capped collections.",
+		)
+	}
+}
+
+func (r *CollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CollectionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.CreateDBCollection(ctx, dbName, data.toNewCollection(collName))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create collection", err)
+		return
+	}
+
+	data.applyCollection(dbName, coll)
+
+	tflog.Trace(ctx, "created collection")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CollectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.GetDBCollection(ctx, dbName, collName)
+	if mongodb.IsNotFound(err) {
+		// The collection was dropped outside of Terraform. Removing it from
+		// the state lets Terraform plan to create it again.
+		tflog.Warn(ctx, "collection not found, removing from state", map[string]any{
+			"db":         dbName,
+			"collection": collName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read collection", err)
+		return
+	}
+	if coll.Type != mongodb.CollectionTypeCollection {
+		resp.Diagnostics.AddError("Unexpected Collection Type",
+			fmt.Sprintf("Expected %s.%s to be a collection, but it is a %s.", dbName, collName, coll.Type))
+		return
+	}
+
+	data.applyCollection(dbName, coll)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *CollectionResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.UpdateDBCollection(ctx, dbName, data.toUpdateCollection(collName, *state))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update collection", err)
+		return
+	}
+
+	data.applyCollection(dbName, coll)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CollectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	if err := r.client.DeleteDBCollection(ctx, dbName, collName); err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "drop collection", err)
+		return
+	}
+}
+
+func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCollectionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "example" {
+  db     = "testdb-collectionresource"
+  name   = "capped"
+  capped = true
+  size   = 10000
+  collation = {
+    locale   = "de"
+    strength = 2
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.example", "id", "testdb-collectionresource.capped"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "capped", "true"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "size", "10000"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "collation.locale", "de"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "collation.strength", "2"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "change_stream_pre_and_post_images", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "mongodb_collection.example",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"size", "collation"},
+			},
+			// Update in place using collMod
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "example" {
+  db     = "testdb-collectionresource"
+  name   = "capped"
+  capped = true
+  size   = 20000
+  max    = 100
+  collation = {
+    locale   = "de"
+    strength = 2
+  }
+  change_stream_pre_and_post_images = true
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.example", "size", "20000"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "max", "100"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "change_stream_pre_and_post_images", "true"),
+				),
+			},
+			// Replace when changing options that collMod cannot change
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "example" {
+  db   = "testdb-collectionresource"
+  name = "capped"
+  collation = {
+    locale = "en"
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.example", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_collection.example", "capped"),
+					resource.TestCheckResourceAttr("mongodb_collection.example", "collation.locale", "en"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccCollectionResourceClustered(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "example" {
+  db                   = "testdb-collectionresource"
+  name                 = "clustered"
+  clustered_index      = {}
+  expire_after_seconds = 3600
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.example", "expire_after_seconds", "3600"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "example" {
+  db              = "testdb-collectionresource"
+  name            = "clustered"
+  clustered_index = {}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_collection.example", "expire_after_seconds"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceSimpleCollation(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_collection" "example" {
+  db   = "testdb-collectionresource"
+  name = "simple-collation"
+  collation = {
+    locale = "simple"
+  }
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.example", "collation.locale", "simple"),
+				),
+			},
+			// MongoDB does not store the simple collation, which must not
+			// cause the collection to be replaced.
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,394 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CollectionValidatorResource{}
+var _ resource.ResourceWithConfigure = &CollectionValidatorResource{}
+var _ resource.ResourceWithImportState = &CollectionValidatorResource{}
+var _ resource.ResourceWithValidateConfig = &CollectionValidatorResource{}
+
+func NewCollectionValidatorResource() resource.Resource {
+	return &CollectionValidatorResource{}
+}
+
+// CollectionValidatorResource defines the resource implementation.
+type CollectionValidatorResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// CollectionValidatorResourceModel describes the resource data model.
+type CollectionValidatorResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	DB               types.String   `tfsdk:"db"`
+	Collection       types.String   `tfsdk:"collection"`
+	Validator        ExtJSON        `tfsdk:"validator"`
+	ValidationLevel  types.String   `tfsdk:"validation_level"`
+	ValidationAction types.String   `tfsdk:"validation_action"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (v CollectionValidatorResourceModel) collectionAndDB() (string, string, error) {
+	return collectionAndDB(v.ID, v.DB, v.Collection)
+}
+
+func (v CollectionValidatorResourceModel) validator() (bson.D, error) {
+	value, err := v.Validator.Unmarshal()
+	if err != nil {
+		return nil, err
+	}
+	validator, ok := value.(bson.D)
+	if !ok {
+		return nil, errors.New("validator must be a document")
+	}
+	return validator, nil
+}
+
+func (v CollectionValidatorResourceModel) toUpdateCollection(collName string) (mongodb.UpdateCollection, error) {
+	validator, err := v.validator()
+	if err != nil {
+		return mongodb.UpdateCollection{}, err
+	}
+	return mongodb.UpdateCollection{
+		Name:             collName,
+		Validator:        validator,
+		ValidationLevel:  v.ValidationLevel.ValueString(),
+		ValidationAction: v.ValidationAction.ValueString(),
+	}, nil
+}
+
+func (v *CollectionValidatorResourceModel) applyCollection(dbName string, coll mongodb.Collection) error {
+	opts := coll.Options
+	v.ID = types.StringValue(dbName + "." + coll.Name)
+	v.DB = types.StringValue(dbName)
+	v.Collection = types.StringValue(coll.Name)
+
+	validator := opts.Validator
+	if validator == nil {
+		// Removing the validator outside of Terraform is reported as drift.
+		validator = bson.D{}
+	}
+	value, err := NewExtJSONFromBSON(validator)
+	if err != nil {
+		return fmt.Errorf("validator: %w", err)
+	}
+	v.Validator = value
+
+	// MongoDB omits the level and action when they are the defaults.
+	v.ValidationLevel = types.StringValue(mongodb.ValidationLevelStrict)
+	if opts.ValidationLevel != "" {
+		v.ValidationLevel = types.StringValue(opts.ValidationLevel)
+	}
+	v.ValidationAction = types.StringValue(mongodb.ValidationActionError)
+	if opts.ValidationAction != "" {
+		v.ValidationAction = types.StringValue(opts.ValidationAction)
+	}
+	return nil
+}
+
+func (r *CollectionValidatorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_validator"
+}
+
+func (r *CollectionValidatorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Collection validator resource.\n\n" +
+			"Manages the schema validation rules of an existing collection using the `collMod` command. " +
+			"Destroying this resource removes the validator from the collection, but keeps the collection and its data.\n" +
+			"See: <https://www.mongodb.com/docs/manual/core/schema-validation/>",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Collection unique ID in MongoDB. Is composed from the `db` and `collection` fields.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"db": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Database of the collection.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: databaseValidators,
+			},
+			"collection": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the existing collection to validate the documents of.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: collectionValidators,
+			},
+			"validator": schema.StringAttribute{
+				CustomType: ExtJSONType{},
+				Required:   true,
+				MarkdownDescription: "Validation rules, as a document in JSON or MongoDB Extended JSON format. " +
+					"Either a `$jsonSchema` document, such as `jsonencode({ \"$jsonSchema\" = jsondecode(file(\"schema.json\")) })`, " +
+					"or query operators, such as `jsonencode({ email = { \"$regex\" = \"@\" } })`. " +
+					"The validator is compared semantically, so formatting does not cause changes.",
+			},
+			"validation_level": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(mongodb.ValidationLevelStrict),
+				MarkdownDescription: "Which documents are validated. " +
+					"`strict` validates all inserts and updates, " +
+					"`moderate` does not validate updates to existing documents that are already invalid, " +
+					"and `off` disables validation. Defaults to `strict`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(mongodb.ValidationLevels...),
+				},
+			},
+			"validation_action": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(mongodb.ValidationActionError),
+				MarkdownDescription: "What happens to invalid documents. " +
+					"`error` rejects them, and `warn` only logs a warning. Defaults to `error`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(mongodb.ValidationActions...),
+				},
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *CollectionValidatorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Only reading the validated attribute, as other attributes may be
+	// unknown, which cannot be read into the model.
+	var validatorJSON ExtJSON
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("validator"), &validatorJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if validatorJSON.IsNull() || validatorJSON.IsUnknown() {
+		return
+	}
+	// Invalid Extended JSON is already reported by the custom type.
+	if value, err := validatorJSON.Unmarshal(); err == nil {
+		if _, ok := value.(bson.D); !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validator"),
+				"Invalid Attribute Configuration",
+				"The validator attribute must be a document, not an array.",
+			)
+		}
+	}
+}
+
+func (r *CollectionValidatorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *CollectionValidatorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CollectionValidatorResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.apply(ctx, data, "create collection validator", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created collection validator")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionValidatorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CollectionValidatorResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.GetDBCollection(ctx, dbName, collName)
+	if mongodb.IsNotFound(err) {
+		// The collection was dropped outside of Terraform, together with
+		// its validator.
+		tflog.Warn(ctx, "collection not found, removing validator from state", map[string]any{
+			"db":         dbName,
+			"collection": collName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read collection validator", err)
+		return
+	}
+
+	if err := data.applyCollection(dbName, coll); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionValidatorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CollectionValidatorResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.apply(ctx, data, "update collection validator", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply sets the validator, level, and action of the collection in a single
+// collMod command, as they are not modified independently in practice.
+func (r *CollectionValidatorResource) apply(ctx context.Context, data *CollectionValidatorResourceModel, action string, diags *diag.Diagnostics) {
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		diags.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	update, err := data.toUpdateCollection(collName)
+	if err != nil {
+		diags.AddAttributeError(path.Root("validator"), "Data Error", fmt.Sprintf("Unable to parse validator, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.UpdateDBCollection(ctx, dbName, update)
+	if err != nil {
+		addClientError(diags, action, err)
+		return
+	}
+
+	if err := data.applyCollection(dbName, coll); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+}
+
+func (r *CollectionValidatorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CollectionValidatorResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	_, err = r.client.UpdateDBCollection(ctx, dbName, mongodb.UpdateCollection{
+		Name:             collName,
+		Validator:        bson.D{},
+		ValidationLevel:  mongodb.ValidationLevelStrict,
+		ValidationAction: mongodb.ValidationActionError,
+	})
+	if err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "remove collection validator", err)
+		return
+	}
+}
+
+func (r *CollectionValidatorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCollectionValidatorResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "example" {
+  db   = "testdb-collectionvalidatorresource"
+  name = "customers"
+}
+
+resource "mongodb_collection_validator" "example" {
+  db         = mongodb_collection.example.db
+  collection = mongodb_collection.example.name
+  validator = jsonencode({
+    "$jsonSchema" = {
+      bsonType = "object"
+      required = ["email"]
+      properties = {
+        email = { bsonType = "string" }
+      }
+    }
+  })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection_validator.example", "id", "testdb-collectionvalidatorresource.customers"),
+					resource.TestCheckResourceAttr("mongodb_collection_validator.example", "validation_level", "strict"),
+					resource.TestCheckResourceAttr("mongodb_collection_validator.example", "validation_action", "error"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "mongodb_collection_validator.example",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"validator"},
+			},
+			// Update in place using collMod
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "example" {
+  db   = "testdb-collectionvalidatorresource"
+  name = "customers"
+}
+
+resource "mongodb_collection_validator" "example" {
+  db                = mongodb_collection.example.db
+  collection        = mongodb_collection.example.name
+  validation_level  = "moderate"
+  validation_action = "warn"
+  validator = jsonencode({
+    email = { "$regex" = "@" }
+  })
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection_validator.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection_validator.example", "validation_level", "moderate"),
+					resource.TestCheckResourceAttr("mongodb_collection_validator.example", "validation_action", "warn"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
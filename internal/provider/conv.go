@@ -5,6 +5,8 @@
 package provider
 
 import (
+	"maps"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -47,3 +49,34 @@ func castToStringSlice[E ~string](slice []E) []string {
 	}
 	return result
 }
+
+// equalUnordered returns true if both slices contain the same elements,
+// ignoring their order. Used to compare Terraform set attributes.
+func equalUnordered[E comparable](a, b []E) bool {
+	return equalUnorderedFunc(a, b, func(x, y E) bool { return x == y })
+}
+
+func equalUnorderedFunc[E any](a, b []E, eq func(E, E) bool) bool {
+	if (a == nil) != (b == nil) || len(a) != len(b) {
+		return false
+	}
+	matched := make([]bool, len(b))
+outer:
+	for _, x := range a {
+		for j, y := range b {
+			if !matched[j] && eq(x, y) {
+				matched[j] = true
+				continue outer
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func equalTypesStringMap(a, b map[string]types.String) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	return maps.EqualFunc(a, b, func(x, y types.String) bool { return x.Equal(y) })
+}
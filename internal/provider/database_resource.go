@@ -0,0 +1,359 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatabaseResource{}
+var _ resource.ResourceWithConfigure = &DatabaseResource{}
+var _ resource.ResourceWithImportState = &DatabaseResource{}
+
+// DefaultInitialCollection is the collection created to ensure that
+// a database exists, as MongoDB does not store empty databases.
+const DefaultInitialCollection = "_terraform"
+
+func NewDatabaseResource() resource.Resource {
+	return &DatabaseResource{}
+}
+
+// DatabaseResource defines the resource implementation.
+type DatabaseResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// DatabaseResourceModel describes the resource data model.
+type DatabaseResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	InitialCollection types.String   `tfsdk:"initial_collection"`
+	DropOnDestroy     types.Bool     `tfsdk:"drop_on_destroy"`
+	ForceDrop         types.Bool     `tfsdk:"force_drop"`
+	SizeOnDisk        types.Int64    `tfsdk:"size_on_disk"`
+	Empty             types.Bool     `tfsdk:"empty"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (d DatabaseResourceModel) dbName() (string, error) {
+	if !d.Name.IsNull() && d.Name.ValueString() != "" {
+		return d.Name.ValueString(), nil
+	}
+	if !d.ID.IsNull() && d.ID.ValueString() != "" {
+		return d.ID.ValueString(), nil
+	}
+	return "", fmt.Errorf("missing database name")
+}
+
+func (d *DatabaseResourceModel) applyDatabase(db mongodb.Database) {
+	d.ID = types.StringValue(db.Name)
+	d.Name = types.StringValue(db.Name)
+	d.SizeOnDisk = types.Int64Value(db.SizeOnDisk)
+	d.Empty = types.BoolValue(db.Empty)
+	// Imported databases have no values for the attributes with defaults.
+	if d.InitialCollection.IsNull() {
+		d.InitialCollection = types.StringValue(DefaultInitialCollection)
+	}
+	if d.DropOnDestroy.IsNull() {
+		d.DropOnDestroy = types.BoolValue(false)
+	}
+	if d.ForceDrop.IsNull() {
+		d.ForceDrop = types.BoolValue(false)
+	}
+}
+
+func (r *DatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+}
+
+func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Database resource.\n\n" +
+			"MongoDB only creates a database when data is first stored in it. " +
+			"This resource ensures the database exists by creating an initial collection in it.\n\n" +
+			"By default, the database is **not** dropped when the resource is destroyed, " +
+			"and is only removed from the Terraform state. See the `drop_on_destroy` attribute.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Database unique ID in MongoDB. Is the same as the `name` field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Name of the database.\n\n" +
+					// Indenting here because the documentation generation doesn't do it
+					"  MongoDB has some restrictions on database names. Such as:\n\n" +
+					"  - Cannot contain any of the following characters (we're following Windows limits): `/\\. \"$*<>:|?`\n" +
+					"  - Cannot be one of the built-in databases `admin`, `config`, or `local`.\n" +
+					"  - Cannot be empty.\n" +
+					"  - Cannot be longer than 64 characters.\n\n" +
+					"  See documentation:\n\n" +
+					"  - <https://www.mongodb.com/docs/v6.0/reference/limits/#naming-restrictions>",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: slices.Concat(
+					databaseValidators,
+					[]validator.String{stringvalidator.NoneOf("admin", "config", "local")},
+				),
+			},
+			"initial_collection": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(DefaultInitialCollection),
+				MarkdownDescription: "Collection that is created to ensure the database exists. " +
+					"Changing this creates the new collection, but does not drop the previous one. " +
+					fmt.Sprintf("Defaults to `%s`.", DefaultInitialCollection),
+				Validators: collectionValidators,
+			},
+			"drop_on_destroy": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Drop the database when the resource is destroyed. " +
+					"When `false`, the database is only removed from the Terraform state. Defaults to `false`.\n\n" +
+					"  As a safety guard, the database is only dropped if it contains no other collections " +
+					"than the `initial_collection`, unless the `force_drop` attribute is also set.",
+			},
+			"force_drop": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Drop the database when the resource is destroyed, even if it contains other collections, " +
+					"and so **permanently deletes all of its data**. " +
+					"Only takes effect when `drop_on_destroy` is also set. Defaults to `false`.",
+			},
+			"size_on_disk": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total size of the database files on disk, in bytes.",
+			},
+			"empty": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the database has no data, as reported by MongoDB.",
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *DatabaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DatabaseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	dbName, err := data.dbName()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve database name, got error: %s", err))
+		return
+	}
+
+	db, err := r.client.CreateDatabase(ctx, dbName, data.InitialCollection.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create database", err)
+		return
+	}
+
+	data.applyDatabase(db)
+
+	tflog.Trace(ctx, "created database")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DatabaseResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	dbName, err := data.dbName()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve database name, got error: %s", err))
+		return
+	}
+
+	db, err := r.client.GetDatabase(ctx, dbName)
+	if mongodb.IsNotFound(err) {
+		// The database was dropped outside of Terraform. Removing it from the
+		// state lets Terraform plan to create it again.
+		tflog.Warn(ctx, "database not found, removing from state", map[string]any{
+			"db": dbName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read database", err)
+		return
+	}
+
+	data.applyDatabase(db)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *DatabaseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	dbName, err := data.dbName()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve database name, got error: %s", err))
+		return
+	}
+
+	// Only the initial collection and the attributes used on destroy can
+	// change, so ensuring that the initial collection exists is sufficient.
+	db, err := r.client.CreateDatabase(ctx, dbName, data.InitialCollection.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update database", err)
+		return
+	}
+
+	data.applyDatabase(db)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *DatabaseResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	dbName, err := data.dbName()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve database name, got error: %s", err))
+		return
+	}
+
+	if !data.DropOnDestroy.ValueBool() {
+		tflog.Info(ctx, "not dropping database as drop_on_destroy is false, only removing from state", map[string]any{
+			"db": dbName,
+		})
+		return
+	}
+
+	if !data.ForceDrop.ValueBool() {
+		names, err := r.client.ListCollectionNames(ctx, dbName)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "list collections", err)
+			return
+		}
+		names = slices.DeleteFunc(names, func(name string) bool {
+			return name == data.InitialCollection.ValueString()
+		})
+		if len(names) > 0 {
+			slices.Sort(names)
+			resp.Diagnostics.AddError("Database Not Empty",
+				fmt.Sprintf("Refusing to drop database %q, as it contains the collections: %s\n\n", dbName, strings.Join(names, ", "))+
+					"Either drop the collections first, or set the \"force_drop\" attribute to true to drop the database and all of its data.")
+			return
+		}
+	}
+
+	if err := r.client.DropDatabase(ctx, dbName); err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "drop database", err)
+		return
+	}
+}
+
+func (r *DatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatabaseResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDatabaseDropped("testdb-databaseresource"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "example" {
+  name            = "testdb-databaseresource"
+  drop_on_destroy = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_database.example", "id", "testdb-databaseresource"),
+					resource.TestCheckResourceAttr("mongodb_database.example", "name", "testdb-databaseresource"),
+					resource.TestCheckResourceAttr("mongodb_database.example", "initial_collection", "_terraform"),
+					resource.TestCheckResourceAttr("mongodb_database.example", "empty", "false"),
+					resource.TestCheckResourceAttrSet("mongodb_database.example", "size_on_disk"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "mongodb_database.example",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_on_destroy", "size_on_disk", "empty"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "example" {
+  name               = "testdb-databaseresource"
+  initial_collection = "marker"
+  drop_on_destroy    = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_database.example", "initial_collection", "marker"),
+				),
+			},
+			// Recreate after dropped outside of Terraform
+			{
+				PreConfig: func() {
+					deleteTestDatabase(t, "testdb-databaseresource")
+				},
+				Config: providerConfig + `
+resource "mongodb_database" "example" {
+  name               = "testdb-databaseresource"
+  initial_collection = "marker"
+  drop_on_destroy    = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_database.example", "id", "testdb-databaseresource"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDatabaseResourceDropGuard(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_database" "example" {
+  name            = "testdb-databaseguard"
+  drop_on_destroy = true
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDatabaseDropped("testdb-databaseguard"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					createTestCollection(t, "testdb-databaseguard", "data")
+				},
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Database Not Empty`),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "example" {
+  name            = "testdb-databaseguard"
+  drop_on_destroy = true
+  force_drop      = true
+}
+`,
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+type clientErrorHint struct {
+	err     error
+	summary string
+	hint    string
+}
+
+var clientErrorHints = []clientErrorHint{
+	{
+		err:     mongodb.ErrUserExists,
+		summary: "User Already Exists",
+		hint: "A user with the same name already exists in this database. " +
+			"Either import the existing user using \"terraform import\", " +
+			"or drop the user from MongoDB before applying again.",
+	},
+	{
+		err:     mongodb.ErrRoleExists,
+		summary: "Role Already Exists",
+		hint: "A role with the same name already exists in this database. " +
+			"Either import the existing role using \"terraform import\", " +
+			"or drop the role from MongoDB before applying again.",
+	},
+	{
+		err:     mongodb.ErrNamespaceExists,
+		summary: "Namespace Already Exists",
+		hint: "A collection or view with the same name already exists in this database. " +
+			"Either import the existing collection or view using \"terraform import\", " +
+			"or drop it from MongoDB before applying again.",
+	},
+	{
+		err:     mongodb.ErrNotFound,
+		summary: "Not Found",
+		hint: "A user, role, or database referenced by this operation does not exist. " +
+			"Ensure that all roles referenced in the \"roles\" attribute exist, " +
+			"and that any mongodb_role resources are created before they are referenced.",
+	},
+	{
+		err:     mongodb.ErrUnauthorized,
+		summary: "Unauthorized",
+		hint: "The MongoDB user used by the provider lacks the privileges for this operation. " +
+			"Grant it a role with the required privilege actions, such as the built-in " +
+			"\"userAdminAnyDatabase\" role for managing users and roles.",
+	},
+	{
+		err:     mongodb.ErrAuthenticationFailed,
+		summary: "Authentication Failed",
+		hint: "MongoDB rejected the provider's credentials. " +
+			"Check the \"username\" and \"password\" provider attributes, " +
+			"as well as any credentials in the connection URI.",
+	},
+	{
+		err:     mongodb.ErrNotPrimary,
+		summary: "Not Primary",
+		hint: "The operation was sent to a MongoDB node that is not the primary of its replica set, " +
+			"or the primary stepped down while running it. " +
+			"If the provider's \"direct_connection\" attribute is set to true, point its \"uri\" attribute at the primary. " +
+			"Otherwise, try applying again once the replica set has elected a new primary.",
+	},
+	{
+		err:     mongodb.ErrInvalidRoleModification,
+		summary: "Invalid Role Modification",
+		hint: "Built-in roles cannot be modified, " +
+			"and a role cannot inherit from itself, neither directly nor via other roles.",
+	},
+	{
+		err:     mongodb.ErrBadValue,
+		summary: "Invalid Value",
+		hint: "MongoDB rejected one of the values in the request, " +
+			"such as an unknown privilege action or authentication mechanism. " +
+			"Check the resource's attributes against the MongoDB documentation.",
+	},
+	{
+		err:     mongodb.ErrTransient,
+		summary: "Transient Error",
+		hint: "The failure is likely temporary, such as from an unreachable host or a replica set election. " +
+			"Try applying again.",
+	},
+}
+
+// addClientError adds an error diagnostic for a failed MongoDB operation.
+// The action is used in the detail message, such as "create user".
+// Known errors get a more specific summary and a remediation hint.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	detail := fmt.Sprintf("Unable to %s, got error: %s", action, err)
+	for _, h := range clientErrorHints {
+		if errors.Is(err, h.err) {
+			diags.AddError(h.summary, detail+"\n\n"+h.hint)
+			return
+		}
+	}
+	diags.AddError("Client Error", detail)
+}
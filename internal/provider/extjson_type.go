@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = ExtJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = ExtJSON{}
+	_ xattr.ValidateableAttribute                = ExtJSON{}
+)
+
+// ExtJSONType is a string attribute type that holds a document or array in
+// MongoDB Extended JSON format, such as a query filter or an aggregation
+// pipeline. Values are compared semantically, so formatting differences
+// such as whitespace do not cause changes.
+//
+// [https://www.mongodb.com/docs/manual/reference/mongodb-extended-json/]
+type ExtJSONType struct {
+	basetypes.StringType
+}
+
+func (t ExtJSONType) String() string {
+	return "provider.ExtJSONType"
+}
+
+func (t ExtJSONType) ValueType(ctx context.Context) attr.Value {
+	return ExtJSON{}
+}
+
+func (t ExtJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(ExtJSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t ExtJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ExtJSON{StringValue: in}, nil
+}
+
+func (t ExtJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return ExtJSON{StringValue: stringValue}, nil
+}
+
+// ExtJSON is the value of an [ExtJSONType] attribute.
+type ExtJSON struct {
+	basetypes.StringValue
+}
+
+func NewExtJSONNull() ExtJSON {
+	return ExtJSON{StringValue: basetypes.NewStringNull()}
+}
+
+func NewExtJSONValue(value string) ExtJSON {
+	return ExtJSON{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewExtJSONFromBSON formats a BSON document or array as relaxed
+// Extended JSON.
+func NewExtJSONFromBSON(value any) (ExtJSON, error) {
+	s, err := formatExtJSON(value)
+	if err != nil {
+		return ExtJSON{}, err
+	}
+	return NewExtJSONValue(s), nil
+}
+
+func (v ExtJSON) Type(ctx context.Context) attr.Type {
+	return ExtJSONType{}
+}
+
+func (v ExtJSON) Equal(o attr.Value) bool {
+	other, ok := o.(ExtJSON)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v ExtJSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(ExtJSON)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	a, err := normalizeExtJSON(v.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	b, err := normalizeExtJSON(newValue.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	return a == b, nil
+}
+
+func (v ExtJSON) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if _, err := parseExtJSON(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Extended JSON",
+			fmt.Sprintf("Attribute %s must be a valid MongoDB Extended JSON document or array, got error: %s", req.Path, err),
+		)
+	}
+}
+
+// Unmarshal parses the value into a BSON document ([bson.D]) or array
+// ([bson.A]). Returns nil if the value is null.
+func (v ExtJSON) Unmarshal() (any, error) {
+	if v.IsNull() || v.IsUnknown() {
+		return nil, nil
+	}
+	return parseExtJSON(v.ValueString())
+}
+
+// parseExtJSON parses a document or array in Extended JSON. The value is
+// wrapped in a document first, as the BSON library only parses documents.
+func parseExtJSON(s string) (any, error) {
+	var wrapper struct {
+		V any `bson:"v"`
+	}
+	if err := bson.UnmarshalExtJSON([]byte(`{"v":`+s+`}`), false, &wrapper); err != nil {
+		return nil, err
+	}
+	switch wrapper.V.(type) {
+	case bson.D, bson.A:
+		return wrapper.V, nil
+	default:
+		return nil, fmt.Errorf("must be a document or an array, got %T", wrapper.V)
+	}
+}
+
+// normalizeExtJSON formats the value as canonical Extended JSON, which keeps
+// the order of document fields as well as the BSON type of all values.
+func normalizeExtJSON(s string) (string, error) {
+	v, err := parseExtJSON(s)
+	if err != nil {
+		return "", err
+	}
+	b, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: v}}, true, false)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func formatExtJSON(value any) (string, error) {
+	b, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+	if err != nil {
+		return "", err
+	}
+	var wrapper struct {
+		V json.RawMessage `json:"v"`
+	}
+	if err := json.Unmarshal(b, &wrapper); err != nil {
+		return "", err
+	}
+	return string(wrapper.V), nil
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultGeneratedPasswordLength = 32
+
+	passwordLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordNumericChars = "0123456789"
+	passwordSpecialChars = "!#$%&*()-_=+[]{}<>:?"
+)
+
+var generatePasswordResourceAttributeSchema = schema.SingleNestedAttribute{
+	Optional: true,
+	MarkdownDescription: "Options for the password that is generated when none of `pwd`, `pwd_wo`, or `pwd_digest` is set. " +
+		"Changing any of the options generates a new password.",
+	Attributes: map[string]schema.Attribute{
+		"length": schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(defaultGeneratedPasswordLength),
+			MarkdownDescription: "Length of the generated password. Defaults to `32`.",
+			Validators: []validator.Int64{
+				int64validator.Between(8, 256),
+			},
+		},
+		"lower": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Include lowercase letters. Defaults to `true`.",
+		},
+		"upper": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Include uppercase letters. Defaults to `true`.",
+		},
+		"numeric": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Include numbers. Defaults to `true`.",
+		},
+		"special": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+			MarkdownDescription: "Include special characters, from the set `" + passwordSpecialChars + "`. " +
+				"Defaults to `false`, as they must be escaped in connection strings.",
+		},
+		"keepers": schema.MapAttribute{
+			Optional: true,
+			MarkdownDescription: "Arbitrary map of values that, when changed, generates a new password. " +
+				"Such as a date to regenerate the password on.",
+			ElementType: types.StringType,
+		},
+	},
+	Validators: []validator.Object{
+		objectvalidator.ConflictsWith(path.MatchRoot("pwd"), path.MatchRoot("pwd_wo"), path.MatchRoot("pwd_digest")),
+	},
+}
+
+type GeneratePasswordResourceModel struct {
+	Length  types.Int64             `tfsdk:"length"`
+	Lower   types.Bool              `tfsdk:"lower"`
+	Upper   types.Bool              `tfsdk:"upper"`
+	Numeric types.Bool              `tfsdk:"numeric"`
+	Special types.Bool              `tfsdk:"special"`
+	Keepers map[string]types.String `tfsdk:"keepers"`
+}
+
+// charClasses returns the enabled character classes. When the model is nil,
+// the default options are used.
+func (g *GeneratePasswordResourceModel) charClasses() []string {
+	if g == nil {
+		return []string{passwordLowerChars, passwordUpperChars, passwordNumericChars}
+	}
+	var classes []string
+	if g.Lower.ValueBool() {
+		classes = append(classes, passwordLowerChars)
+	}
+	if g.Upper.ValueBool() {
+		classes = append(classes, passwordUpperChars)
+	}
+	if g.Numeric.ValueBool() {
+		classes = append(classes, passwordNumericChars)
+	}
+	if g.Special.ValueBool() {
+		classes = append(classes, passwordSpecialChars)
+	}
+	return classes
+}
+
+func (g *GeneratePasswordResourceModel) length() int {
+	if g == nil || g.Length.IsNull() {
+		return defaultGeneratedPasswordLength
+	}
+	return int(g.Length.ValueInt64())
+}
+
+// generate returns a new random password, which contains at least one
+// character of each of the enabled character classes.
+func (g *GeneratePasswordResourceModel) generate() (string, error) {
+	classes := g.charClasses()
+	if len(classes) == 0 {
+		return "", errors.New("no character classes enabled")
+	}
+	length := g.length()
+	if length < len(classes) {
+		return "", errors.New("length is shorter than the number of enabled character classes")
+	}
+	allChars := strings.Join(classes, "")
+	pwd := make([]byte, length)
+	for i := range pwd {
+		chars := allChars
+		if i < len(classes) {
+			chars = classes[i]
+		}
+		n, err := randomInt(len(chars))
+		if err != nil {
+			return "", err
+		}
+		pwd[i] = chars[n]
+	}
+	// Shuffle, so the guaranteed characters are not always first.
+	for i := len(pwd) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		pwd[i], pwd[j] = pwd[j], pwd[i]
+	}
+	return string(pwd), nil
+}
+
+func randomInt(max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, err
+	}
+	return int(n.Int64()), nil
+}
+
+// generatedPasswordPlanModifier plans the generated password of a user.
+// A password is generated when none of "pwd", "pwd_wo", or "pwd_digest" is configured,
+// and is kept until the "generate_password" options change.
+type generatedPasswordPlanModifier struct{}
+
+var _ planmodifier.String = generatedPasswordPlanModifier{}
+
+func (generatedPasswordPlanModifier) Description(context.Context) string {
+	return "Generates a new password when the generate_password options change."
+}
+
+func (m generatedPasswordPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (generatedPasswordPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Explicit password, or destroying the resource.
+	if !req.ConfigValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var db, pwdWO, pwdDigest types.String
+	var options, stateOptions types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("db"), &db)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pwd_wo"), &pwdWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pwd_digest"), &pwdDigest)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("generate_password"), &options)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if db.IsUnknown() {
+		return
+	}
+	if db.ValueString() == mongodb.DBExternal || !pwdWO.IsNull() || !pwdDigest.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+	if req.State.Raw.IsNull() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("generate_password"), &stateOptions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.StateValue.IsNull() && options.Equal(stateOptions) {
+		resp.PlanValue = req.StateValue
+	} else {
+		resp.PlanValue = types.StringUnknown()
+	}
+}
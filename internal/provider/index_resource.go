@@ -0,0 +1,706 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IndexResource{}
+var _ resource.ResourceWithConfigure = &IndexResource{}
+var _ resource.ResourceWithImportState = &IndexResource{}
+
+// Index key types, in addition to the field names.
+//
+// [https://www.mongodb.com/docs/manual/core/indexes/index-types/]
+var indexKeyTypes = []string{"1", "-1", "text", "2d", "2dsphere", "hashed"}
+
+const indexKeyTypeText = "text"
+
+func NewIndexResource() resource.Resource {
+	return &IndexResource{}
+}
+
+// IndexResource defines the resource implementation.
+type IndexResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// IndexResourceModel describes the resource data model.
+type IndexResourceModel struct {
+	ID                      types.String            `tfsdk:"id"`
+	DB                      types.String            `tfsdk:"db"`
+	Collection              types.String            `tfsdk:"collection"`
+	Name                    types.String            `tfsdk:"name"`
+	Keys                    []IndexKeyResourceModel `tfsdk:"keys"`
+	Unique                  types.Bool              `tfsdk:"unique"`
+	Sparse                  types.Bool              `tfsdk:"sparse"`
+	Hidden                  types.Bool              `tfsdk:"hidden"`
+	PartialFilterExpression ExtJSON                 `tfsdk:"partial_filter_expression"`
+	ExpireAfterSeconds      types.Int64             `tfsdk:"expire_after_seconds"`
+	Collation               *CollationResourceModel `tfsdk:"collation"`
+	Timeouts                timeouts.Value          `tfsdk:"timeouts"`
+}
+
+type IndexKeyResourceModel struct {
+	Field types.String `tfsdk:"field"`
+	Type  types.String `tfsdk:"type"`
+}
+
+func (i IndexResourceModel) indexCollectionAndDB() (string, string, string, error) {
+	if !i.Name.IsNull() && !i.Name.IsUnknown() && !i.Collection.IsNull() && !i.DB.IsNull() {
+		indexName := i.Name.ValueString()
+		collName := i.Collection.ValueString()
+		dbName := i.DB.ValueString()
+		if indexName != "" && collName != "" && dbName != "" {
+			return indexName, collName, dbName, nil
+		}
+	}
+	if i.ID.IsNull() || i.ID.ValueString() == "" {
+		return "", "", "", errors.New("missing index ID")
+	}
+	return parseIndexID(i.ID.ValueString(), nil)
+}
+
+// parseIndexID parses an index ID in the format "db.collection.index".
+// Database names cannot contain dots, but both collection and index names
+// can, such as the index "address.city_1". The collection is therefore the
+// longest of the existing collections that prefixes the rest of the ID.
+// When none matches, the index name is after the last dot.
+func parseIndexID(id string, collNames []string) (string, string, string, error) {
+	dbName, rest, ok := strings.Cut(id, ".")
+	sep := strings.LastIndexByte(rest, '.')
+	if !ok || sep == -1 {
+		return "", "", "", errors.New("malformed index ID, must be in the format db.collection.index")
+	}
+	collName, indexName := rest[:sep], rest[sep+1:]
+	matched := false
+	for _, name := range collNames {
+		if strings.HasPrefix(rest, name+".") && len(rest) > len(name)+1 &&
+			(!matched || len(name) > len(collName)) {
+			collName, indexName = name, rest[len(name)+1:]
+			matched = true
+		}
+	}
+	if dbName == "" {
+		return "", "", "", errors.New("malformed index ID, missing db")
+	}
+	if collName == "" {
+		return "", "", "", errors.New("malformed index ID, missing collection")
+	}
+	if indexName == "" {
+		return "", "", "", errors.New("malformed index ID, missing index")
+	}
+	return indexName, collName, dbName, nil
+}
+
+// defaultIndexName returns the same index name that MongoDB and its drivers
+// generate, such as "field1_1_field2_-1".
+func defaultIndexName(keys []IndexKeyResourceModel) string {
+	parts := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		parts = append(parts, key.Field.ValueString(), key.Type.ValueString())
+	}
+	return strings.Join(parts, "_")
+}
+
+// defaultIndexNamePlanModifier plans the default index name from the keys
+// when no name is configured, so changing the keys also changes the name.
+type defaultIndexNamePlanModifier struct{}
+
+var _ planmodifier.String = defaultIndexNamePlanModifier{}
+
+func (defaultIndexNamePlanModifier) Description(context.Context) string {
+	return "Defaults to a name generated from the keys."
+}
+
+func (m defaultIndexNamePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (defaultIndexNamePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Explicit name, or destroying the resource.
+	if !req.ConfigValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var keysList types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("keys"), &keysList)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keysList.IsUnknown() || keysList.IsNull() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	var keys []IndexKeyResourceModel
+	resp.Diagnostics.Append(keysList.ElementsAs(ctx, &keys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, key := range keys {
+		if key.Field.IsUnknown() || key.Type.IsUnknown() {
+			resp.PlanValue = types.StringUnknown()
+			return
+		}
+	}
+	resp.PlanValue = types.StringValue(defaultIndexName(keys))
+}
+
+func (i IndexResourceModel) toIndex(indexName string) (mongodb.Index, error) {
+	index := mongodb.Index{
+		Name:               indexName,
+		Unique:             i.Unique.ValueBool(),
+		Sparse:             i.Sparse.ValueBool(),
+		Hidden:             i.Hidden.ValueBool(),
+		ExpireAfterSeconds: i.ExpireAfterSeconds.ValueInt64Pointer(),
+		Collation:          i.Collation.toCollation(),
+	}
+	for _, key := range i.Keys {
+		index.Key = append(index.Key, bson.E{Key: key.Field.ValueString(), Value: fromIndexKeyType(key.Type.ValueString())})
+	}
+	filter, err := i.PartialFilterExpression.Unmarshal()
+	if err != nil {
+		return mongodb.Index{}, fmt.Errorf("partial_filter_expression: %w", err)
+	}
+	index.PartialFilterExpression = filter
+	return index, nil
+}
+
+func fromIndexKeyType(keyType string) any {
+	switch keyType {
+	case "1":
+		return 1
+	case "-1":
+		return -1
+	default:
+		return keyType
+	}
+}
+
+func toIndexKeyType(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toTypesIndexKeys converts the key of an index returned by MongoDB.
+// The fields of a text index are stored as weights, and replaced in the key
+// with the "_fts" and "_ftsx" fields.
+func toTypesIndexKeys(index mongodb.Index) []IndexKeyResourceModel {
+	var keys []IndexKeyResourceModel
+	for _, elem := range index.Key {
+		switch elem.Key {
+		case "_fts":
+			for _, weight := range index.Weights {
+				keys = append(keys, IndexKeyResourceModel{
+					Field: types.StringValue(weight.Key),
+					Type:  types.StringValue(indexKeyTypeText),
+				})
+			}
+		case "_ftsx":
+		default:
+			keys = append(keys, IndexKeyResourceModel{
+				Field: types.StringValue(elem.Key),
+				Type:  types.StringValue(toIndexKeyType(elem.Value)),
+			})
+		}
+	}
+	return keys
+}
+
+// equalIndexKeys returns true if both keys result in the same index.
+// The order of text fields does not matter, as MongoDB does not keep it.
+func equalIndexKeys(a, b []IndexKeyResourceModel) bool {
+	return slices.Equal(normalizeIndexKeys(a), normalizeIndexKeys(b))
+}
+
+func normalizeIndexKeys(keys []IndexKeyResourceModel) []IndexKeyResourceModel {
+	var result, text []IndexKeyResourceModel
+	for _, key := range keys {
+		if key.Type.ValueString() != indexKeyTypeText {
+			result = append(result, key)
+			continue
+		}
+		if len(text) == 0 {
+			result = append(result, IndexKeyResourceModel{Type: key.Type})
+		}
+		text = append(text, key)
+	}
+	slices.SortFunc(text, func(a, b IndexKeyResourceModel) int {
+		return strings.Compare(a.Field.ValueString(), b.Field.ValueString())
+	})
+	return append(result, text...)
+}
+
+func (i *IndexResourceModel) applyIndex(dbName, collName string, index mongodb.Index) error {
+	i.ID = types.StringValue(dbName + "." + collName + "." + index.Name)
+	i.DB = types.StringValue(dbName)
+	i.Collection = types.StringValue(collName)
+	i.Name = types.StringValue(index.Name)
+	if keys := toTypesIndexKeys(index); !equalIndexKeys(i.Keys, keys) {
+		i.Keys = keys
+	}
+	i.Unique = types.BoolValue(index.Unique)
+	i.Sparse = types.BoolValue(index.Sparse)
+	i.Hidden = types.BoolValue(index.Hidden)
+	i.PartialFilterExpression = NewExtJSONNull()
+	if index.PartialFilterExpression != nil {
+		filter, err := NewExtJSONFromBSON(index.PartialFilterExpression)
+		if err != nil {
+			return fmt.Errorf("partial filter expression: %w", err)
+		}
+		i.PartialFilterExpression = filter
+	}
+	i.ExpireAfterSeconds = types.Int64PointerValue(index.ExpireAfterSeconds)
+	i.Collation = toTypesCollationResource(i.Collation, index.Collation)
+	return nil
+}
+
+// toUpdateIndex returns the changes to the index that can be made in place.
+func (i IndexResourceModel) toUpdateIndex(indexName string, state IndexResourceModel) mongodb.UpdateIndex {
+	update := mongodb.UpdateIndex{Name: indexName}
+	if !i.Hidden.Equal(state.Hidden) {
+		update.Hidden = i.Hidden.ValueBoolPointer()
+	}
+	if !i.ExpireAfterSeconds.Equal(state.ExpireAfterSeconds) {
+		update.ExpireAfterSeconds = i.ExpireAfterSeconds.ValueInt64Pointer()
+	}
+	return update
+}
+
+// withoutDefaultCollation removes the collation of the index if it is the
+// default collation of the collection, as MongoDB reports the collection's
+// collation for indexes that were created without one.
+func (r *IndexResource) withoutDefaultCollation(ctx context.Context, dbName, collName string, index mongodb.Index) (mongodb.Index, error) {
+	if index.Collation == nil {
+		return index, nil
+	}
+	coll, err := r.client.GetDBCollection(ctx, dbName, collName)
+	if err != nil {
+		return mongodb.Index{}, err
+	}
+	if reflect.DeepEqual(coll.Options.Collation, index.Collation) {
+		index.Collation = nil
+	}
+	return index, nil
+}
+
+func (r *IndexResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index"
+}
+
+func (r *IndexResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Index resource.\n\n" +
+			"The `hidden` and `expire_after_seconds` attributes are updated in place. " +
+			"Changing any other attribute drops and rebuilds the index.\n\n" +
+			"Creating an index waits for its build to finish, which is limited by the create timeout. " +
+			"If the timeout is reached, MongoDB continues building the index in the background.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Index unique ID in MongoDB. Is composed from the `db`, `collection`, and `name` fields.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"db": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Database of the collection.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: databaseValidators,
+			},
+			"collection": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Collection to create the index on. Is created if it does not exist.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: collectionValidators,
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Name of the index. Defaults to a name generated from the keys, " +
+					"such as `field1_1_field2_-1`.",
+				PlanModifiers: []planmodifier.String{
+					defaultIndexNamePlanModifier{},
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"keys": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Ordered fields of the index and their index types.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Required: true,
+							MarkdownDescription: "Field to index, using dot notation for embedded fields. " +
+								"Use `$**` or `path.$**` for a wildcard index.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"type": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString("1"),
+							MarkdownDescription: "Index type of the field. Either `1` or `-1` for ascending or descending order, " +
+								"or one of `text`, `2d`, `2dsphere`, or `hashed`. Defaults to `1`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(indexKeyTypes...),
+							},
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"unique": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Reject documents with duplicate values for the indexed fields. Defaults to `false`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"sparse": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Only index documents that contain the indexed fields. Defaults to `false`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"hidden": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Hide the index from the query planner, to evaluate the impact of dropping it. " +
+					"Defaults to `false`.",
+			},
+			"partial_filter_expression": schema.StringAttribute{
+				CustomType: ExtJSONType{},
+				Optional:   true,
+				MarkdownDescription: "Only index the documents that match this filter, " +
+					"as a document in JSON or MongoDB Extended JSON format, such as `jsonencode({ rating = { \"$gt\" = 5 } })`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Create a TTL index, which deletes documents this many seconds after the time in the indexed date field. " +
+					"Changing the number of seconds updates the index in place, " +
+					"while adding or removing it rebuilds the index.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+					}, "Adding or removing the TTL rebuilds the index.", "Adding or removing the TTL rebuilds the index."),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"collation": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Collation of the index. Defaults to the collation of the collection.\n" +
+					"  See: <https://www.mongodb.com/docs/manual/reference/collation/>",
+				Attributes: collationResourceAttributesSchema,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *IndexResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *IndexResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if data.Name.IsUnknown() || data.Name.IsNull() {
+		data.Name = types.StringValue(defaultIndexName(data.Keys))
+	}
+
+	indexName, collName, dbName, err := data.indexCollectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve index, collection, and database, got error: %s", err))
+		return
+	}
+
+	newIndex, err := data.toIndex(indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to parse index, got error: %s", err))
+		return
+	}
+
+	index, err := r.client.CreateDBCollectionIndex(ctx, dbName, collName, newIndex)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create index", err)
+		return
+	}
+
+	if data.Collation == nil {
+		index, err = r.withoutDefaultCollation(ctx, dbName, collName, index)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read collection collation", err)
+			return
+		}
+	}
+
+	if err := data.applyIndex(dbName, collName, index); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created index")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IndexResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	indexName, collName, dbName, err := data.indexCollectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve index, collection, and database, got error: %s", err))
+		return
+	}
+
+	index, err := r.client.GetDBCollectionIndex(ctx, dbName, collName, indexName)
+	if mongodb.IsNotFound(err) {
+		// The index or its collection was dropped outside of Terraform.
+		// Removing it from the state lets Terraform plan to create it again.
+		tflog.Warn(ctx, "index not found, removing from state", map[string]any{
+			"db":         dbName,
+			"collection": collName,
+			"index":      indexName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read index", err)
+		return
+	}
+
+	if data.Collation == nil {
+		index, err = r.withoutDefaultCollation(ctx, dbName, collName, index)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read collection collation", err)
+			return
+		}
+	}
+
+	if err := data.applyIndex(dbName, collName, index); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *IndexResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	indexName, collName, dbName, err := data.indexCollectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve index, collection, and database, got error: %s", err))
+		return
+	}
+
+	index, err := r.client.UpdateDBCollectionIndex(ctx, dbName, collName, data.toUpdateIndex(indexName, *state))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update index", err)
+		return
+	}
+
+	if data.Collation == nil {
+		index, err = r.withoutDefaultCollation(ctx, dbName, collName, index)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read collection collation", err)
+			return
+		}
+	}
+
+	if err := data.applyIndex(dbName, collName, index); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IndexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *IndexResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	indexName, collName, dbName, err := data.indexCollectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve index, collection, and database, got error: %s", err))
+		return
+	}
+
+	if err := r.client.DeleteDBCollectionIndex(ctx, dbName, collName, indexName); err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "drop index", err)
+		return
+	}
+}
+
+func (r *IndexResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The existing collections are needed to know where the collection name
+	// ends, as both the collection and index names may contain dots.
+	dbName, _, _ := strings.Cut(req.ID, ".")
+	var collNames []string
+	if dbName != "" {
+		var err error
+		collNames, err = r.client.ListCollectionNames(ctx, dbName)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "list collections", err)
+			return
+		}
+	}
+	indexName, collName, dbName, err := parseIndexID(req.ID, collNames)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Unable to parse index ID %q, got error: %s", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("db"), dbName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection"), collName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), indexName)...)
+}
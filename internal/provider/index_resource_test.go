@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccIndexResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "example" {
+  db         = "testdb-indexresource"
+  collection = "customers"
+  keys = [
+    { field = "lastName" },
+    { field = "age", type = "-1" },
+  ]
+  unique = true
+  partial_filter_expression = jsonencode({
+    age = { "$gt" = 18 }
+  })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.example", "id", "testdb-indexresource.customers.lastName_1_age_-1"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "name", "lastName_1_age_-1"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "keys.#", "2"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "keys.0.type", "1"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "keys.1.type", "-1"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "unique", "true"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "hidden", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "mongodb_index.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update in place using collMod
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "example" {
+  db         = "testdb-indexresource"
+  collection = "customers"
+  keys = [
+    { field = "lastName" },
+    { field = "age", type = "-1" },
+  ]
+  unique = true
+  hidden = true
+  partial_filter_expression = <<-EOT
+    { "age": { "$gt": 18 } }
+  EOT
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.example", "hidden", "true"),
+				),
+			},
+			// Replace with a new default name when changing the keys
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "example" {
+  db         = "testdb-indexresource"
+  collection = "customers"
+  keys = [
+    { field = "firstName" },
+  ]
+  unique = true
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.example", plancheck.ResourceActionCreateBeforeDestroy),
+						plancheck.ExpectKnownValue("mongodb_index.example", tfjsonpath.New("name"), knownvalue.StringExact("firstName_1")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.example", "id", "testdb-indexresource.customers.firstName_1"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "name", "firstName_1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccIndexResourceTTL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "example" {
+  db                   = "testdb-indexresource"
+  collection           = "sessions"
+  name                 = "expiry"
+  keys                 = [{ field = "createdAt" }]
+  expire_after_seconds = 3600
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.example", "id", "testdb-indexresource.sessions.expiry"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "expire_after_seconds", "3600"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "example" {
+  db                   = "testdb-indexresource"
+  collection           = "sessions"
+  name                 = "expiry"
+  keys                 = [{ field = "createdAt" }]
+  expire_after_seconds = 7200
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.example", "expire_after_seconds", "7200"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceText(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "example" {
+  db         = "testdb-indexresource"
+  collection = "articles"
+  keys = [
+    { field = "title", type = "text" },
+    { field = "body", type = "text" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.example", "name", "title_text_body_text"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "keys.0.field", "title"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "keys.1.field", "body"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceCollectionCollation(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_collection" "example" {
+  db   = "testdb-indexresource"
+  name = "collated"
+  collation = {
+    locale = "de"
+  }
+}
+
+resource "mongodb_index" "example" {
+  db         = mongodb_collection.example.db
+  collection = mongodb_collection.example.name
+  keys       = [{ field = "lastName" }]
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_index.example", "collation"),
+				),
+			},
+			// MongoDB reports the collection's collation for the index,
+			// which must not cause the index to be replaced.
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceEmbeddedField(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "example" {
+  db         = "testdb-indexresource"
+  collection = "customers.archive"
+  keys       = [{ field = "address.city" }]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.example", "id", "testdb-indexresource.customers.archive.address.city_1"),
+					resource.TestCheckResourceAttr("mongodb_index.example", "name", "address.city_1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "mongodb_index.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseIndexID(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		collNames []string
+		wantIndex string
+		wantColl  string
+	}{
+		{
+			name:      "simple",
+			id:        "db.coll.a_1",
+			collNames: []string{"coll"},
+			wantIndex: "a_1",
+			wantColl:  "coll",
+		},
+		{
+			name:      "embedded field",
+			id:        "db.coll.address.city_1",
+			collNames: []string{"coll", "other"},
+			wantIndex: "address.city_1",
+			wantColl:  "coll",
+		},
+		{
+			name:      "longest collection",
+			id:        "db.coll.archive.address.city_1",
+			collNames: []string{"coll", "coll.archive"},
+			wantIndex: "address.city_1",
+			wantColl:  "coll.archive",
+		},
+		{
+			name:      "no matching collection",
+			id:        "db.coll.address.city_1",
+			collNames: nil,
+			wantIndex: "city_1",
+			wantColl:  "coll.address",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			indexName, collName, dbName, err := parseIndexID(tc.id, tc.collNames)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if dbName != "db" || collName != tc.wantColl || indexName != tc.wantIndex {
+				t.Errorf("want db %q, collection %q, index %q, got %q, %q, %q",
+					"db", tc.wantColl, tc.wantIndex, dbName, collName, indexName)
+			}
+		})
+	}
+}
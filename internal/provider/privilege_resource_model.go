@@ -48,6 +48,12 @@ func (r PrivilegeResourceModel) toPrivilege() mongodb.Privilege {
 	}
 }
 
+// Equal returns true if both privileges target the same resource with the
+// same set of actions.
+func (r PrivilegeResourceModel) Equal(other PrivilegeResourceModel) bool {
+	return r.Resource == other.Resource && equalUnordered(r.Actions, other.Actions)
+}
+
 func fromTypesPrivilegeResourceSlice(privileges []PrivilegeResourceModel) []mongodb.Privilege {
 	result := make([]mongodb.Privilege, len(privileges))
 	for i, priv := range privileges {
@@ -7,23 +7,32 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &mongodbProvider{}
+	_ provider.Provider                   = &mongodbProvider{}
+	_ provider.ProviderWithValidateConfig = &mongodbProvider{}
 
-	DefaultTimeout = 30 * time.Second
+	DefaultTimeout        = 30 * time.Second
+	DefaultConnectRetries = 3
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -72,6 +81,133 @@ For a full reference of connection URI options available for the Go SDK, see thi
 				Sensitive:           true,
 				MarkdownDescription: "Allows specifying the password for the connection. You must also set the `username` attribute when using this attribute.",
 			},
+			"auth_source": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Database to authenticate the user against. Setting this will override the `authSource` option in the connection URI. " +
+					"Defaults to `admin`, or `$external` for external authentication mechanisms such as `MONGODB-X509`. " +
+					"Can also be set with the `MONGODB_AUTH_SOURCE` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+				},
+			},
+			"auth_mechanism": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Authentication mechanism to use, instead of negotiating it with the server. " +
+					"Setting this will override the `authMechanism` option in the connection URI. " +
+					"Can also be set with the `MONGODB_AUTH_MECHANISM` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(castToStringSlice(mongodb.Mechanisms)...),
+				},
+			},
+			"auth_mechanism_properties": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Additional properties for the authentication mechanism, such as `SERVICE_NAME` for `GSSAPI`. " +
+					"Setting this will override the `authMechanismProperties` option in the connection URI. " +
+					"Can also be set with the `MONGODB_AUTH_MECHANISM_PROPERTIES` environment variable, " +
+					"using the same `KEY:value,KEY:value` format as the connection URI.",
+			},
+			"direct_connection": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Connect directly to the host in the connection URI, instead of discovering the rest of the replica set. " +
+					"Setting this will override the `directConnection` option in the connection URI.\n\n" +
+					"  By default, the provider discovers the replica set topology and sends all changes to users and roles to the primary. " +
+					"Direct connections are mainly useful when bootstrapping a single node, before the replica set is initiated.",
+			},
+			"connect_retries": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: fmt.Sprintf("How many times to retry connecting to MongoDB after a transient failure, "+
+					"such as when the server is unreachable. Retries use an exponential backoff, "+
+					"and are limited by the timeout of the resource or data source that needs the connection. "+
+					"Defaults to `%d`.", DefaultConnectRetries),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"connect_timeout": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Limits how long connecting to MongoDB may take in total, including retries, " +
+					"as a duration string such as `\"30s\"` or `\"2m\"`. " +
+					"By default, connecting is only limited by the timeout of the resource or data source that needs the connection.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"default_timeouts": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: fmt.Sprintf("Default timeouts of all resources and data sources, "+
+					"as duration strings such as `\"30s\"` or `\"2m\"`. "+
+					"The `timeouts` attribute of each resource or data source takes precedence over these. "+
+					"Each defaults to `\"%s\"`.", DefaultTimeout),
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Default timeout for creating resources.",
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"read": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Default timeout for reading resources and data sources.",
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"update": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Default timeout for updating resources.",
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"delete": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Default timeout for deleting resources.",
+						Validators:          []validator.String{durationValidator{}},
+					},
+				},
+			},
+			"tls": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "TLS configuration for the connection. Setting this will override any TLS options used in the connection URI.\n\n" +
+					"  Certificates and keys are given as PEM encoded content instead of file paths, " +
+					"so they can be passed directly from other Terraform resources.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Enables or disables TLS. Defaults to `true` when the `tls` attribute is set.",
+					},
+					"ca_pem": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "PEM encoded certificate authorities used to verify the server certificate. Defaults to the system's certificate pool.",
+					},
+					"cert_pem": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "PEM encoded client certificate sent to the server. You must also set the `key_pem` attribute when using this attribute.",
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("key_pem")),
+						},
+					},
+					"key_pem": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "PEM encoded private key of the client certificate. You must also set the `cert_pem` attribute when using this attribute.",
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("cert_pem")),
+						},
+					},
+					"insecure_skip_verify": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Disables verification of the server certificate and hostname. **Do not** use this outside of testing.",
+					},
+					"server_name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Overrides the hostname used to verify the server certificate.",
+					},
+					"x509_auth": schema.BoolAttribute{
+						Optional: true,
+						MarkdownDescription: "Authenticate using the client certificate with the `MONGODB-X509` mechanism, " +
+							"as the user in the `$external` database named after the certificate's subject. " +
+							"Same as setting `auth_mechanism` to `MONGODB-X509`, and conflicts with any other `auth_mechanism`. " +
+							"Cannot be combined with the `password` attribute.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -81,6 +217,132 @@ type mongodbProviderModel struct {
 	URI      types.String `tfsdk:"uri"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+
+	AuthSource              types.String `tfsdk:"auth_source"`
+	AuthMechanism           types.String `tfsdk:"auth_mechanism"`
+	AuthMechanismProperties types.Map    `tfsdk:"auth_mechanism_properties"`
+
+	DirectConnection types.Bool   `tfsdk:"direct_connection"`
+	ConnectRetries   types.Int64  `tfsdk:"connect_retries"`
+	ConnectTimeout   types.String `tfsdk:"connect_timeout"`
+	TLS              *tlsModel    `tfsdk:"tls"`
+
+	DefaultTimeouts *defaultTimeoutsModel `tfsdk:"default_timeouts"`
+}
+
+// defaultTimeoutsModel maps the provider's default_timeouts attribute to a Go type.
+type defaultTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+func (t defaultTimeoutsModel) isUnknown() bool {
+	return t.Create.IsUnknown() ||
+		t.Read.IsUnknown() ||
+		t.Update.IsUnknown() ||
+		t.Delete.IsUnknown()
+}
+
+func (t defaultTimeoutsModel) toDefaultTimeouts() (defaultTimeouts, error) {
+	var errs []error
+	parse := func(name string, v types.String) time.Duration {
+		if v.IsNull() {
+			return DefaultTimeout
+		}
+		d, err := time.ParseDuration(v.ValueString())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		return d
+	}
+	timeouts := defaultTimeouts{
+		Create: parse("create", t.Create),
+		Read:   parse("read", t.Read),
+		Update: parse("update", t.Update),
+		Delete: parse("delete", t.Delete),
+	}
+	return timeouts, errors.Join(errs...)
+}
+
+// providerData is passed from the provider to all resources and data sources.
+type providerData struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// defaultTimeouts are used for the resource and data source timeouts that
+// are not set in their own "timeouts" attribute.
+type defaultTimeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+func newDefaultTimeouts() defaultTimeouts {
+	return defaultTimeouts{
+		Create: DefaultTimeout,
+		Read:   DefaultTimeout,
+		Update: DefaultTimeout,
+		Delete: DefaultTimeout,
+	}
+}
+
+// tlsModel maps the provider's tls attribute to a Go type.
+type tlsModel struct {
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	CAPEM              types.String `tfsdk:"ca_pem"`
+	CertPEM            types.String `tfsdk:"cert_pem"`
+	KeyPEM             types.String `tfsdk:"key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ServerName         types.String `tfsdk:"server_name"`
+	X509Auth           types.Bool   `tfsdk:"x509_auth"`
+}
+
+func (t tlsModel) isUnknown() bool {
+	return t.Enabled.IsUnknown() ||
+		t.CAPEM.IsUnknown() ||
+		t.CertPEM.IsUnknown() ||
+		t.KeyPEM.IsUnknown() ||
+		t.InsecureSkipVerify.IsUnknown() ||
+		t.ServerName.IsUnknown() ||
+		t.X509Auth.IsUnknown()
+}
+
+func (t tlsModel) toTLSConfig() mongodb.TLSConfig {
+	return mongodb.TLSConfig{
+		Enabled:            t.Enabled.IsNull() || t.Enabled.ValueBool(),
+		CAPEM:              t.CAPEM.ValueString(),
+		CertPEM:            t.CertPEM.ValueString(),
+		KeyPEM:             t.KeyPEM.ValueString(),
+		InsecureSkipVerify: t.InsecureSkipVerify.ValueBool(),
+		ServerName:         t.ServerName.ValueString(),
+	}
+}
+
+// ValidateConfig validates combinations of provider attributes that are
+// known before the provider is configured.
+func (p *mongodbProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var x509Auth types.Bool
+	var authMechanism types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tls").AtName("x509_auth"), &x509Auth)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_mechanism"), &authMechanism)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !x509Auth.ValueBool() || authMechanism.IsNull() || authMechanism.IsUnknown() {
+		return
+	}
+	if authMechanism.ValueString() != string(mongodb.MechanismMONGODBX509) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls").AtName("x509_auth"),
+			"Conflicting MongoDB authentication mechanism",
+			fmt.Sprintf("The \"auth_mechanism\" attribute must be %q or unset when \"tls.x509_auth\" is true, but got %q.",
+				mongodb.MechanismMONGODBX509, authMechanism.ValueString()),
+		)
+	}
 }
 
 func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -122,6 +384,78 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.AuthSource.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_source"),
+			"Unknown MongoDB authentication source",
+			"The provider cannot create the MongoDB client as there is an unknown configuration value for the MongoDB authentication source. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MONGODB_AUTH_SOURCE environment variable.",
+		)
+	}
+
+	if config.AuthMechanism.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_mechanism"),
+			"Unknown MongoDB authentication mechanism",
+			"The provider cannot create the MongoDB client as there is an unknown configuration value for the MongoDB authentication mechanism. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MONGODB_AUTH_MECHANISM environment variable.",
+		)
+	}
+
+	if config.AuthMechanismProperties.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_mechanism_properties"),
+			"Unknown MongoDB authentication mechanism properties",
+			"The provider cannot create the MongoDB client as there is an unknown configuration value for the MongoDB authentication mechanism properties. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MONGODB_AUTH_MECHANISM_PROPERTIES environment variable.",
+		)
+	}
+
+	if config.DirectConnection.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("direct_connection"),
+			"Unknown MongoDB direct connection setting",
+			"The provider cannot create the MongoDB client as there is an unknown configuration value for the MongoDB direct connection setting. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ConnectRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connect_retries"),
+			"Unknown MongoDB connect retries",
+			"The provider cannot create the MongoDB client as there is an unknown configuration value for the MongoDB connect retries. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ConnectTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connect_timeout"),
+			"Unknown MongoDB connect timeout",
+			"The provider cannot create the MongoDB client as there is an unknown configuration value for the MongoDB connect timeout. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultTimeouts != nil && config.DefaultTimeouts.isUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_timeouts"),
+			"Unknown default timeouts",
+			"The provider cannot be configured as there is an unknown configuration value for the default timeouts. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.TLS != nil && config.TLS.isUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls"),
+			"Unknown MongoDB TLS configuration",
+			"The provider cannot create the MongoDB client as there is an unknown configuration value for the MongoDB TLS configuration. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -132,6 +466,16 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 	uri := os.Getenv("MONGODB_URI")
 	username := os.Getenv("MONGODB_USERNAME")
 	password := os.Getenv("MONGODB_PASSWORD")
+	authSource := os.Getenv("MONGODB_AUTH_SOURCE")
+	authMechanism := os.Getenv("MONGODB_AUTH_MECHANISM")
+	authMechanismProperties, err := parseAuthMechanismProperties(os.Getenv("MONGODB_AUTH_MECHANISM_PROPERTIES"))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_mechanism_properties"),
+			"Invalid MongoDB authentication mechanism properties",
+			"The provider cannot parse the MONGODB_AUTH_MECHANISM_PROPERTIES environment variable: "+err.Error(),
+		)
+	}
 
 	if !config.URI.IsNull() {
 		uri = config.URI.ValueString()
@@ -145,6 +489,19 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 		password = config.Password.ValueString()
 	}
 
+	if !config.AuthSource.IsNull() {
+		authSource = config.AuthSource.ValueString()
+	}
+
+	if !config.AuthMechanism.IsNull() {
+		authMechanism = config.AuthMechanism.ValueString()
+	}
+
+	if !config.AuthMechanismProperties.IsNull() {
+		authMechanismProperties = nil
+		resp.Diagnostics.Append(config.AuthMechanismProperties.ElementsAs(ctx, &authMechanismProperties, false)...)
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -166,17 +523,122 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if authMechanism != "" && !slices.Contains(mongodb.Mechanisms, mongodb.Mechanism(authMechanism)) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_mechanism"),
+			"Invalid MongoDB authentication mechanism",
+			fmt.Sprintf("The authentication mechanism %q is not supported. Must be one of: %s",
+				authMechanism, strings.Join(castToStringSlice(mongodb.Mechanisms), ", ")),
+		)
+	}
+
+	cred := mongodb.Credentials{
+		Username:            username,
+		Password:            password,
+		Mechanism:           mongodb.Mechanism(authMechanism),
+		MechanismProperties: authMechanismProperties,
+		Source:              authSource,
+	}
+
+	opts := mongodb.Options{
+		ConnectRetries: DefaultConnectRetries,
+	}
+	if !config.ConnectRetries.IsNull() {
+		opts.ConnectRetries = int(config.ConnectRetries.ValueInt64())
+	}
+	if !config.ConnectTimeout.IsNull() {
+		connectTimeout, err := time.ParseDuration(config.ConnectTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("connect_timeout"),
+				"Invalid MongoDB connect timeout",
+				"The provider cannot parse the MongoDB connect timeout: "+err.Error(),
+			)
+		}
+		opts.ConnectTimeout = connectTimeout
+	}
+	if !config.DirectConnection.IsNull() {
+		direct := config.DirectConnection.ValueBool()
+		opts.Direct = &direct
+	}
+	if config.TLS != nil {
+		tlsConfig := config.TLS.toTLSConfig()
+		if err := tlsConfig.Validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tls"),
+				"Invalid MongoDB TLS configuration",
+				"The provider cannot create the MongoDB client as the TLS configuration is invalid: "+err.Error(),
+			)
+		}
+		opts.TLS = &tlsConfig
+
+		if config.TLS.X509Auth.ValueBool() {
+			if password != "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("tls").AtName("x509_auth"),
+					"Conflicting MongoDB credentials",
+					"The password must not be specified when authenticating using X.509 client certificates.",
+				)
+			}
+			if !tlsConfig.Enabled {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("tls").AtName("x509_auth"),
+					"Conflicting MongoDB TLS configuration",
+					"TLS must be enabled when authenticating using X.509 client certificates.",
+				)
+			}
+			if cred.Mechanism != "" && cred.Mechanism != mongodb.MechanismMONGODBX509 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("tls").AtName("x509_auth"),
+					"Conflicting MongoDB authentication mechanism",
+					fmt.Sprintf("The authentication mechanism must be %q or unset when authenticating using X.509 client certificates, but got %q.",
+						mongodb.MechanismMONGODBX509, cred.Mechanism),
+				)
+			}
+			cred.Mechanism = mongodb.MechanismMONGODBX509
+		}
+	}
+
+	timeouts := newDefaultTimeouts()
+	if config.DefaultTimeouts != nil {
+		timeouts, err = config.DefaultTimeouts.toDefaultTimeouts()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_timeouts"),
+				"Invalid default timeouts",
+				"The provider cannot parse the default timeouts: "+err.Error(),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client := mongodb.New(uri, mongodb.Credentials{
-		Username: username,
-		Password: password,
-	})
+	data := &providerData{
+		client:   mongodb.New(uri, cred, opts),
+		timeouts: timeouts,
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
+}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+// parseAuthMechanismProperties parses properties in the same format as the
+// authMechanismProperties connection URI option, e.g "KEY1:value1,KEY2:value2".
+func parseAuthMechanismProperties(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	props := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid property %q, must be in the format KEY:value", pair)
+		}
+		props[key] = value
+	}
+	return props, nil
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -191,5 +653,13 @@ func (p *mongodbProvider) Resources(_ context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		NewUserResource,
 		NewRoleResource,
+		NewDatabaseResource,
+		NewCollectionResource,
+		NewIndexResource,
+		NewViewResource,
+		NewTimeseriesCollectionResource,
+		NewCollectionValidatorResource,
+		NewUserRoleGrantResource,
+		NewRoleGrantResource,
 	}
 }
@@ -6,13 +6,17 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
-	"strings"
+	"regexp"
 	"testing"
 
 	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 var (
@@ -40,14 +44,17 @@ func getMongoURI() string {
 }
 
 func createTestUser(t *testing.T, dbName, userName string) {
-	db := mongodb.New(mongodbUri, mongodb.Credentials{})
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
 	if _, err := db.CreateDBUser(context.Background(), dbName, mongodb.NewUser{
 		User:     userName,
 		Password: "secret1234",
 		Roles: []mongodb.RoleRef{
 			mongodb.RoleSameDBRef("readWrite"),
 		},
-	}); err != nil && !strings.Contains(err.Error(), "already exists") {
+	}); err != nil && !errors.Is(err, mongodb.ErrUserExists) {
 		t.Fatalf("create test user: %s", err)
 	}
 	t.Cleanup(func() {
@@ -56,3 +63,258 @@ func createTestUser(t *testing.T, dbName, userName string) {
 		}
 	})
 }
+
+func deleteTestUser(t *testing.T, dbName, userName string) {
+	db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+	if err := db.DeleteDBUser(context.Background(), dbName, userName); err != nil {
+		t.Fatalf("delete test user: %s", err)
+	}
+}
+
+func updateTestUserPassword(t *testing.T, dbName, userName, password string) {
+	db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+	if _, err := db.UpdateDBUser(context.Background(), dbName, mongodb.UpdateUser{
+		User:     userName,
+		Password: &password,
+	}); err != nil {
+		t.Fatalf("update test user password: %s", err)
+	}
+}
+
+func updateTestUserCustomData(t *testing.T, dbName, userName string, customData map[string]string) {
+	db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+	if _, err := db.UpdateDBUser(context.Background(), dbName, mongodb.UpdateUser{
+		User:       userName,
+		CustomData: &customData,
+	}); err != nil {
+		t.Fatalf("update test user custom data: %s", err)
+	}
+}
+
+func deleteTestRole(t *testing.T, dbName, roleName string) {
+	db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+	if err := db.DeleteDBRole(context.Background(), dbName, roleName); err != nil {
+		t.Fatalf("delete test role: %s", err)
+	}
+}
+
+func createTestCollection(t *testing.T, dbName, collName string) {
+	db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+	if _, err := db.CreateDatabase(context.Background(), dbName, collName); err != nil {
+		t.Fatalf("create test collection: %s", err)
+	}
+}
+
+func deleteTestDatabase(t *testing.T, dbName string) {
+	db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+	if err := db.DropDatabase(context.Background(), dbName); err != nil {
+		t.Fatalf("delete test database: %s", err)
+	}
+}
+
+func testCheckDatabaseDropped(dbName string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+		_, err := db.GetDatabase(context.Background(), dbName)
+		if err == nil {
+			return fmt.Errorf("database %q still exists", dbName)
+		}
+		if !mongodb.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+func TestAccProviderInvalidTLS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  uri = "` + mongodbUri + `"
+  tls = {
+    ca_pem = "not a certificate"
+  }
+}
+
+data "mongodb_users" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Invalid MongoDB TLS configuration`),
+			},
+		},
+	})
+}
+
+func TestAccProviderAuthSource(t *testing.T) {
+	createTestUser(t, "testdb-authsource", "test-user")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  uri            = "` + mongodbUri + `"
+  username       = "test-user"
+  password       = "secret1234"
+  auth_source    = "testdb-authsource"
+  auth_mechanism = "SCRAM-SHA-256"
+}
+
+data "mongodb_users" "test" {
+  db = "testdb-authsource"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.mongodb_users.test", "users.0.user", "test-user"),
+				),
+			},
+			{
+				Config: `
+provider "mongodb" {
+  uri            = "` + mongodbUri + `"
+  auth_mechanism = "NOT-A-MECHANISM"
+}
+
+data "mongodb_users" "test" {
+  db = "testdb-authsource"
+}
+`,
+				ExpectError: regexp.MustCompile(`auth_mechanism`),
+			},
+			{
+				Config: `
+provider "mongodb" {
+  uri            = "` + mongodbUri + `"
+  auth_mechanism = "SCRAM-SHA-256"
+  tls = {
+    x509_auth = true
+  }
+}
+
+data "mongodb_users" "test" {
+  db = "testdb-authsource"
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting MongoDB authentication mechanism`),
+			},
+		},
+	})
+}
+
+func TestAccProviderDirectConnection(t *testing.T) {
+	createTestUser(t, "testdb-direct", "test-user")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  uri               = "` + mongodbUri + `"
+  direct_connection = true
+}
+
+data "mongodb_users" "test" {
+  db = "testdb-direct"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_users.test", "users.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderConnectRetries(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  uri             = "mongodb://localhost:1/?serverSelectionTimeoutMS=100"
+  connect_retries = 2
+}
+
+data "mongodb_users" "test" {
+  db = "testdb"
+  timeouts = {
+    read = "10s"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`server selection`),
+			},
+		},
+	})
+}
+
+func TestAccProviderDefaultTimeouts(t *testing.T) {
+	createTestUser(t, "testdb-timeouts", "test-user")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  uri             = "` + mongodbUri + `"
+  connect_timeout = "20s"
+  default_timeouts = {
+    read = "2m"
+  }
+}
+
+data "mongodb_users" "test" {
+  db = "testdb-timeouts"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_users.test", "users.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderConnectTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  uri             = "mongodb://localhost:1/?serverSelectionTimeoutMS=100"
+  connect_retries = 100
+  connect_timeout = "2s"
+}
+
+data "mongodb_users" "test" {
+  db = "testdb"
+}
+`,
+				ExpectError: regexp.MustCompile(`server selection`),
+			},
+			{
+				Config: `
+provider "mongodb" {
+  uri = "` + mongodbUri + `"
+  default_timeouts = {
+    create = "soon"
+  }
+}
+
+data "mongodb_users" "test" {
+  db = "testdb"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Duration`),
+			},
+		},
+	})
+}
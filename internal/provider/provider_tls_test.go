@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProviderX509Auth(t *testing.T) {
+	mongod := startTLSMongod(t)
+
+	mongod.createExternalUser(t, mongod.client.subject, mongodb.RoleDBRef{Role: "root", DB: "admin"})
+	unknownClient := createTestCert(t, &mongod.ca, pkix.Name{CommonName: "unknown-client", Organization: []string{"client"}})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccX509ProviderConfig(mongod, unknownClient) + testAccX509UserConfig,
+				ExpectError: regexp.MustCompile(`Authentication Failed`),
+			},
+			{
+				Config: testAccX509ProviderConfig(mongod, mongod.client) + testAccX509UserConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "id", "testdb-x509.test-user"),
+				),
+			},
+		},
+	})
+}
+
+const testAccX509UserConfig = `
+resource "mongodb_user" "test" {
+  user  = "test-user"
+  db    = "testdb-x509"
+  pwd   = "secret1234"
+  roles = [
+    { role = "readWrite" },
+  ]
+}
+`
+
+func testAccX509ProviderConfig(mongod testTLSMongod, client testCert) string {
+	return fmt.Sprintf(`
+provider "mongodb" {
+  uri = %q
+  tls = {
+    ca_pem    = %q
+    cert_pem  = %q
+    key_pem   = %q
+    x509_auth = true
+  }
+}
+`, mongod.uri, mongod.ca.certPEM, client.certPEM, client.keyPEM)
+}
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+	subject string
+}
+
+type testTLSMongod struct {
+	uri    string
+	ca     testCert
+	server testCert
+	client testCert
+	admin  mongodb.Credentials
+}
+
+// startTLSMongod starts a mongod process that requires TLS and
+// authentication, using certificates generated for the test. An admin user
+// is created through the localhost exception. The test is skipped if mongod
+// is not found in the PATH.
+func startTLSMongod(t *testing.T) testTLSMongod {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	mongodPath, err := exec.LookPath("mongod")
+	if err != nil {
+		t.Skip("Skipping test that requires mongod in the PATH")
+	}
+
+	ca := createTestCert(t, nil, pkix.Name{CommonName: "test-ca", Organization: []string{"ca"}})
+	server := createTestCert(t, &ca, pkix.Name{CommonName: "localhost", Organization: []string{"server"}})
+	client := createTestCert(t, &ca, pkix.Name{CommonName: "test-client", Organization: []string{"client"}})
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	serverFile := filepath.Join(dir, "server.pem")
+	writeTestFile(t, caFile, ca.certPEM)
+	writeTestFile(t, serverFile, server.certPEM+server.keyPEM)
+	dbPath := filepath.Join(dir, "db")
+	if err := os.Mkdir(dbPath, 0o700); err != nil {
+		t.Fatalf("create mongod db path: %s", err)
+	}
+
+	port := freeTestPort(t)
+	cmd := exec.Command(mongodPath,
+		"--port", strconv.Itoa(port),
+		"--bind_ip", "127.0.0.1",
+		"--dbpath", dbPath,
+		"--auth",
+		"--tlsMode", "requireTLS",
+		"--tlsCertificateKeyFile", serverFile,
+		"--tlsCAFile", caFile,
+	)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start mongod: %s", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	addr := net.JoinHostPort("localhost", strconv.Itoa(port))
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("wait for mongod: %s", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	mongod := testTLSMongod{
+		uri:    "mongodb://" + addr + "/?tls=true",
+		ca:     ca,
+		server: server,
+		client: client,
+		admin: mongodb.Credentials{
+			Username:  "test-admin",
+			Password:  "secret1234",
+			Mechanism: mongodb.MechanismSCRAMSHA256,
+		},
+	}
+	// The localhost exception only allows creating the first user, and only
+	// in the admin database. It is closed once the user exists, so reading
+	// back the created user fails as unauthorized.
+	if _, err := mongod.newClient(mongodb.Credentials{}).CreateDBUser(context.Background(), "admin", mongodb.NewUser{
+		User:     mongod.admin.Username,
+		Password: mongod.admin.Password,
+		Roles:    []mongodb.RoleRef{mongodb.RoleDBRef{Role: "root", DB: "admin"}},
+	}); err != nil && !errors.Is(err, mongodb.ErrUnauthorized) {
+		t.Fatalf("create admin test user: %s", err)
+	}
+	return mongod
+}
+
+func (m testTLSMongod) newClient(cred mongodb.Credentials) *mongodb.Client {
+	return mongodb.New(m.uri, cred, mongodb.Options{
+		TLS: &mongodb.TLSConfig{
+			Enabled: true,
+			CAPEM:   m.ca.certPEM,
+			CertPEM: m.client.certPEM,
+			KeyPEM:  m.client.keyPEM,
+		},
+	})
+}
+
+func (m testTLSMongod) createExternalUser(t *testing.T, subject string, roles ...mongodb.RoleRef) {
+	if _, err := m.newClient(m.admin).CreateDBUser(context.Background(), mongodb.DBExternal, mongodb.NewUser{
+		User:  subject,
+		Roles: roles,
+	}); err != nil {
+		t.Fatalf("create external test user: %s", err)
+	}
+}
+
+// createTestCert creates a certificate signed by the parent,
+// or a self-signed CA certificate if the parent is nil.
+func createTestCert(t *testing.T, parent *testCert, subject pkix.Name) testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %s", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("generate serial number: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %s", err)
+	}
+	return testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})),
+		subject: cert.Subject.String(),
+	}
+}
+
+func writeTestFile(t *testing.T, name, content string) {
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %s", name, err)
+	}
+}
+
+func freeTestPort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("find free port: %s", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
@@ -23,6 +23,7 @@ var resourceResourceAttributesSchema = map[string]schema.Attribute{
 		Validators: []validator.Bool{
 			boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("db")),
 			boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("collection")),
+			boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("system_buckets")),
 		},
 	},
 	"any_resource": schema.BoolAttribute{
@@ -33,6 +34,7 @@ var resourceResourceAttributesSchema = map[string]schema.Attribute{
 		Validators: []validator.Bool{
 			boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("db")),
 			boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("collection")),
+			boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("system_buckets")),
 		},
 	},
 	"db": schema.StringAttribute{
@@ -43,14 +45,32 @@ var resourceResourceAttributesSchema = map[string]schema.Attribute{
 			"If only the `db` attribute is an empty string (`\"\"`), " +
 			"the resource is all collections with the specified `collection` name across all databases." +
 			"If only the `collection` attribute is an empty string (`\"\"`), " +
-			"the resource is the specified database, excluding the system collections.",
+			"the resource is the specified database, excluding the system collections. " +
+			"May instead be paired with the `system_buckets` attribute.",
 		Validators: append(optionalDatabaseValidators, []validator.String{
-			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("collection")),
+			stringvalidator.AtLeastOneOf(
+				path.MatchRelative().AtParent().AtName("collection"),
+				path.MatchRelative().AtParent().AtName("system_buckets"),
+			),
 		}...),
 	},
 	"collection": schema.StringAttribute{
 		Optional:            true,
 		MarkdownDescription: "Specify which collection to target. Must be paired with the `db` attribute.",
+		Validators: []validator.String{
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("db")),
+			stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("system_buckets")),
+		},
+	},
+	"system_buckets": schema.StringAttribute{
+		Optional: true,
+		MarkdownDescription: "Specify which time series collection to target the internal `system.buckets` collection of, " +
+			"such as for granting access to the raw buckets of a `mongodb_timeseries_collection`. " +
+			"Must be paired with the `db` attribute. " +
+			"If the `system_buckets` attribute is an empty string (`\"\"`), " +
+			"the resource is the buckets of all time series collections. " +
+			"If the `db` attribute is an empty string (`\"\"`), the resource spans all databases.\n" +
+			"  See: <https://www.mongodb.com/docs/manual/reference/resource-document/#time-series-collections>",
 		Validators: []validator.String{
 			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("db")),
 		},
@@ -58,10 +78,11 @@ var resourceResourceAttributesSchema = map[string]schema.Attribute{
 }
 
 type ResourceResourceModel struct {
-	Cluster     types.Bool   `tfsdk:"cluster"`
-	AnyResource types.Bool   `tfsdk:"any_resource"`
-	DB          types.String `tfsdk:"db"`
-	Collection  types.String `tfsdk:"collection"`
+	Cluster       types.Bool   `tfsdk:"cluster"`
+	AnyResource   types.Bool   `tfsdk:"any_resource"`
+	DB            types.String `tfsdk:"db"`
+	Collection    types.String `tfsdk:"collection"`
+	SystemBuckets types.String `tfsdk:"system_buckets"`
 }
 
 func (r ResourceResourceModel) toResource() mongodb.Resource {
@@ -71,6 +92,12 @@ func (r ResourceResourceModel) toResource() mongodb.Resource {
 	if !r.AnyResource.IsNull() && r.AnyResource.ValueBool() {
 		return mongodb.ResourceAny{AnyResource: true}
 	}
+	if !r.SystemBuckets.IsNull() {
+		return mongodb.ResourceSystemBuckets{
+			DB:            r.DB.ValueString(),
+			SystemBuckets: r.SystemBuckets.ValueString(),
+		}
+	}
 	return mongodb.ResourceCollection{
 		DB:         r.DB.ValueString(),
 		Collection: r.Collection.ValueString(),
@@ -88,6 +115,11 @@ func toTypesResourceResource(resource mongodb.Resource) (ResourceResourceModel,
 			DB:         types.StringValue(resource.DB),
 			Collection: types.StringValue(resource.Collection),
 		}, nil
+	case mongodb.ResourceSystemBuckets:
+		return ResourceResourceModel{
+			DB:            types.StringValue(resource.DB),
+			SystemBuckets: types.StringValue(resource.SystemBuckets),
+		}, nil
 	default:
 		return ResourceResourceModel{}, fmt.Errorf("unsupported resource type: %T", resource)
 	}
@@ -0,0 +1,377 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleGrantResource{}
+var _ resource.ResourceWithConfigure = &RoleGrantResource{}
+var _ resource.ResourceWithImportState = &RoleGrantResource{}
+
+func NewRoleGrantResource() resource.Resource {
+	return &RoleGrantResource{}
+}
+
+// RoleGrantResource defines the resource implementation.
+type RoleGrantResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// RoleGrantResourceModel describes the resource data model.
+type RoleGrantResourceModel struct {
+	ID            types.String            `tfsdk:"id"`
+	Role          types.String            `tfsdk:"role"`
+	DB            types.String            `tfsdk:"db"`
+	Privilege     *PrivilegeResourceModel `tfsdk:"privilege"`
+	InheritedRole *RoleRefResourceModel   `tfsdk:"inherited_role"`
+	Timeouts      timeouts.Value          `tfsdk:"timeouts"`
+}
+
+// privilegeGrantIDPrefix marks the IDs of privilege grants, which cannot be
+// imported as the ID does not contain the full privilege.
+const privilegeGrantIDPrefix = "privilege:"
+
+// roleAndDB returns the role and database names, either from the attributes
+// or from an imported ID. The inherited role is also set from an imported ID.
+func (g *RoleGrantResourceModel) roleAndDB() (string, string, error) {
+	if !g.Role.IsNull() && !g.DB.IsNull() && (g.Privilege != nil || g.InheritedRole != nil) {
+		role := g.Role.ValueString()
+		db := g.DB.ValueString()
+		if role != "" && db != "" {
+			return role, db, nil
+		}
+	}
+	db, role, inherited, err := parseGrantID(g.ID.ValueString())
+	if err != nil {
+		return "", "", err
+	}
+	g.InheritedRole = &inherited
+	return role, db, nil
+}
+
+func (g *RoleGrantResourceModel) applyRole(role mongodb.Role) {
+	g.Role = types.StringValue(role.Role)
+	g.DB = types.StringValue(role.DB)
+	if g.InheritedRole != nil {
+		roleDB := g.InheritedRole.DB.ValueString()
+		if g.InheritedRole.DB.IsNull() {
+			roleDB = role.DB
+		}
+		g.ID = types.StringValue(grantID(role.DB, role.Role, roleDB, g.InheritedRole.Role.ValueString()))
+		return
+	}
+	actions := fromTypesStringSlice[string](g.Privilege.Actions)
+	slices.Sort(actions)
+	g.ID = types.StringValue(role.DB + "." + role.Role + "/" + privilegeGrantIDPrefix +
+		formatResource(g.Privilege.Resource.toResource()) + ":" + strings.Join(actions, ","))
+}
+
+// formatResource returns a short description of the resource, for use in IDs.
+func formatResource(resource mongodb.Resource) string {
+	switch resource := resource.(type) {
+	case mongodb.ResourceCluster:
+		return "cluster"
+	case mongodb.ResourceAny:
+		return "anyResource"
+	case mongodb.ResourceSystemBuckets:
+		return "system_buckets:" + resource.DB + "." + resource.SystemBuckets
+	case mongodb.ResourceCollection:
+		return resource.DB + "." + resource.Collection
+	default:
+		return fmt.Sprintf("%v", resource)
+	}
+}
+
+// isGranted returns true if the role has the granted privilege or inherited
+// role. All other privileges and roles are ignored, as they may be managed
+// elsewhere.
+func (g RoleGrantResourceModel) isGranted(role mongodb.Role) bool {
+	if g.InheritedRole != nil {
+		return slices.ContainsFunc(role.Roles, func(inherited mongodb.RoleDBRef) bool {
+			return g.InheritedRole.equalRoleRef(role.DB, inherited)
+		})
+	}
+	// MongoDB merges the actions of all privileges on the same resource.
+	resource := g.Privilege.Resource.toResource()
+	for _, priv := range role.Privileges {
+		if priv.Resource.Union != resource {
+			continue
+		}
+		for _, action := range g.Privilege.Actions {
+			if !slices.Contains(priv.Actions, action.ValueString()) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (r *RoleGrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_grant"
+}
+
+func (r *RoleGrantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role grant resource.\n\n" +
+			"Grants a single privilege or inherited role to an existing role, " +
+			"without affecting the other privileges and inherited roles of the role. " +
+			"This allows multiple Terraform configurations to extend the same role, " +
+			"including roles that are not managed by Terraform.\n\n" +
+			"Do not combine with the `privileges` or `roles` attributes of a `mongodb_role` resource for the same role, " +
+			"as those attributes revoke everything that they do not list.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Grant unique ID. For inherited roles, it is in the format `db.role/roleDB.inheritedRole`. " +
+					"For privileges, it also contains the resource and actions of the privilege.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the role to grant to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"db": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Database of the role to grant to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: databaseValidators,
+			},
+			"privilege": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Privilege to grant to the role. Conflicts with `inherited_role`.\n" +
+					"  See: <https://www.mongodb.com/docs/manual/reference/command/grantPrivilegesToRole/>",
+				Attributes: privilegeResourceNestedSchema.Attributes,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRoot("inherited_role")),
+				},
+			},
+			"inherited_role": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Role for the role to inherit privileges from. Conflicts with `privilege`.\n" +
+					"  See: <https://www.mongodb.com/docs/manual/reference/command/grantRolesToRole/>",
+				Attributes: roleRefGrantAttributesSchema,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *RoleGrantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *RoleGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RoleGrantResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	roleName, dbName, err := data.roleAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve role and database, got error: %s", err))
+		return
+	}
+
+	var role mongodb.Role
+	if data.InheritedRole != nil {
+		role, err = r.client.GrantRolesToRole(ctx, dbName, roleName, []mongodb.RoleRef{data.InheritedRole.toRoleRef()})
+	} else {
+		role, err = r.client.GrantPrivilegesToRole(ctx, dbName, roleName, []mongodb.Privilege{data.Privilege.toPrivilege()})
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "grant to role", err)
+		return
+	}
+
+	data.applyRole(role)
+
+	tflog.Trace(ctx, "granted to role")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RoleGrantResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	roleName, dbName, err := data.roleAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve role and database, got error: %s", err))
+		return
+	}
+
+	role, err := r.client.GetDBRole(ctx, dbName, roleName)
+	if mongodb.IsNotFound(err) {
+		tflog.Warn(ctx, "role not found, removing role grant from state", map[string]any{
+			"db":   dbName,
+			"role": roleName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read role", err)
+		return
+	}
+	if !data.isGranted(role) {
+		// The grant was revoked outside of Terraform. Removing it from the
+		// state lets Terraform plan to grant it again.
+		tflog.Warn(ctx, "grant not found on role, removing role grant from state", map[string]any{
+			"db":   dbName,
+			"role": roleName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.applyRole(role)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RoleGrantResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All attributes other than the timeouts require replacing the grant,
+	// so there is nothing to update in MongoDB.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RoleGrantResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	roleName, dbName, err := data.roleAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve role and database, got error: %s", err))
+		return
+	}
+
+	if data.InheritedRole != nil {
+		err = r.client.RevokeRolesFromRole(ctx, dbName, roleName, []mongodb.RoleRef{data.InheritedRole.toRoleRef()})
+	} else {
+		err = r.client.RevokePrivilegesFromRole(ctx, dbName, roleName, []mongodb.Privilege{data.Privilege.toPrivilege()})
+	}
+	// The grant is already gone if the role was deleted.
+	if err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "revoke from role", err)
+		return
+	}
+}
+
+func (r *RoleGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if strings.Contains(req.ID, "/"+privilegeGrantIDPrefix) {
+		resp.Diagnostics.AddError(
+			"Unsupported Import",
+			"Only grants of inherited roles can be imported, in the format db.role/roleDB.inheritedRole. "+
+				"Privilege grants must be created again, which does not change the role if it already has the privilege.",
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
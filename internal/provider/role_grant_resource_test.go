@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoleGrantResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "shared" {
+  db   = "testdb-rolegrant"
+  role = "shared-role"
+}
+
+resource "mongodb_role_grant" "privilege" {
+  db   = mongodb_role.shared.db
+  role = mongodb_role.shared.role
+  privilege = {
+    resource = { db = "testdb-rolegrant", collection = "orders" }
+    actions  = ["find", "insert"]
+  }
+}
+
+resource "mongodb_role_grant" "other_privilege" {
+  db   = mongodb_role.shared.db
+  role = mongodb_role.shared.role
+  privilege = {
+    resource = { db = "testdb-rolegrant", collection = "orders" }
+    actions  = ["update"]
+  }
+}
+
+resource "mongodb_role_grant" "inherited" {
+  db             = mongodb_role.shared.db
+  role           = mongodb_role.shared.role
+  inherited_role = { role = "read", db = "testdb-rolegrant-other" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_role_grant.privilege", "id", "testdb-rolegrant.shared-role/privilege:testdb-rolegrant.orders:find,insert"),
+					resource.TestCheckResourceAttr("mongodb_role_grant.inherited", "id", "testdb-rolegrant.shared-role/testdb-rolegrant-other.read"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "mongodb_role_grant.inherited",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "mongodb_role_grant.privilege",
+				ImportState:  true,
+				ExpectError:  regexp.MustCompile("Unsupported Import"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -35,7 +35,8 @@ func NewRoleResource() resource.Resource {
 
 // RoleResource defines the resource implementation.
 type RoleResource struct {
-	client *mongodb.Client
+	client   *mongodb.Client
+	timeouts defaultTimeouts
 }
 
 // RoleResourceModel describes the resource data model.
@@ -46,6 +47,8 @@ type RoleResourceModel struct {
 	Roles      []RoleRefResourceModel   `tfsdk:"roles"`
 	Privileges []PrivilegeResourceModel `tfsdk:"privileges"`
 	Timeouts   timeouts.Value           `tfsdk:"timeouts"`
+
+	AuthenticationRestrictions []AuthenticationRestrictionResourceModel `tfsdk:"authentication_restrictions"`
 }
 
 func (u RoleResourceModel) roleAndDB() (string, string, error) {
@@ -86,9 +89,31 @@ func (u *RoleResourceModel) applyRole(role mongodb.Role) error {
 		}
 		u.Privileges = privs
 	}
+	if u.AuthenticationRestrictions != nil {
+		u.AuthenticationRestrictions = toTypesAuthenticationRestrictionResourceSlice(role.AuthenticationRestrictions)
+	}
 	return nil
 }
 
+// toUpdateRole returns the updateRole command that changes the role from its
+// prior state to the planned state. Only the changed fields are included.
+func (u RoleResourceModel) toUpdateRole(roleName string, state RoleResourceModel) mongodb.UpdateRole {
+	update := mongodb.UpdateRole{Role: roleName}
+	if !equalUnorderedFunc(u.Privileges, state.Privileges, PrivilegeResourceModel.Equal) {
+		privileges := fromTypesPrivilegeResourceSlice(u.Privileges)
+		update.Privileges = &privileges
+	}
+	if !equalUnordered(u.Roles, state.Roles) {
+		roles := fromTypesRoleRefResourceSlice(u.Roles)
+		update.Roles = &roles
+	}
+	if !equalUnorderedFunc(u.AuthenticationRestrictions, state.AuthenticationRestrictions, AuthenticationRestrictionResourceModel.Equal) {
+		restrictions := fromTypesAuthenticationRestrictionResourceSlice(u.AuthenticationRestrictions)
+		update.AuthenticationRestrictions = &restrictions
+	}
+	return update
+}
+
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_role"
 }
@@ -164,7 +189,8 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Privileges this role has.",
 				NestedObject:        privilegeResourceNestedSchema,
 			},
-			"timeouts": timeouts.AttributesAll(ctx),
+			"authentication_restrictions": authenticationRestrictionResourceAttributeSchema,
+			"timeouts":                    timeouts.AttributesAll(ctx),
 		},
 	}
 }
@@ -175,17 +201,18 @@ func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*mongodb.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *mongodb.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.timeouts = data.timeouts
 }
 
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -197,7 +224,7 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	createTimeout, diags := data.Timeouts.Create(ctx, DefaultTimeout)
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -215,9 +242,11 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Role:       roleName,
 		Roles:      fromTypesRoleRefResourceSlice(data.Roles),
 		Privileges: fromTypesPrivilegeResourceSlice(data.Privileges),
+
+		AuthenticationRestrictions: fromTypesAuthenticationRestrictionResourceSlice(data.AuthenticationRestrictions),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create role, got error: %s", err))
+		addClientError(&resp.Diagnostics, "create role", err)
 		return
 	}
 
@@ -243,7 +272,7 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	readTimeout, diags := data.Timeouts.Read(ctx, DefaultTimeout)
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -258,8 +287,18 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	role, err := r.client.GetDBRole(ctx, dbName, roleName)
+	if mongodb.IsNotFound(err) {
+		// The role was deleted outside of Terraform. Removing it from the state
+		// lets Terraform plan to create it again.
+		tflog.Warn(ctx, "role not found, removing from state", map[string]any{
+			"db":   dbName,
+			"role": roleName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read role", err)
 		return
 	}
 
@@ -273,15 +312,16 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *RoleResourceModel
+	var data, state *RoleResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	updateTimeout, diags := data.Timeouts.Update(ctx, DefaultTimeout)
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -295,13 +335,9 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	role, err := r.client.UpdateDBRole(ctx, dbName, mongodb.UpdateRole{
-		Role:       roleName,
-		Roles:      fromTypesRoleRefResourceSlice(data.Roles),
-		Privileges: fromTypesPrivilegeResourceSlice(data.Privileges),
-	})
+	role, err := r.client.UpdateDBRole(ctx, dbName, data.toUpdateRole(roleName, *state))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update role, got error: %s", err))
+		addClientError(&resp.Diagnostics, "update role", err)
 		return
 	}
 
@@ -323,7 +359,7 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	deleteTimeout, diags := data.Timeouts.Delete(ctx, DefaultTimeout)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -337,8 +373,8 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	if err := r.client.DeleteDBRole(ctx, dbName, roleName); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete role, got error: %s", err))
+	if err := r.client.DeleteDBRole(ctx, dbName, roleName); err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete role", err)
 		return
 	}
 }
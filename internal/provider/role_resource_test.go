@@ -78,7 +78,91 @@ resource "mongodb_role" "example" {
 					resource.TestCheckResourceAttr("mongodb_role.example", "roles.0.role", "read"),
 				),
 			},
+			// Recreate after deleted outside of Terraform
+			{
+				PreConfig: func() {
+					deleteTestRole(t, "testdb-roleresource", "test-role")
+				},
+				Config: providerConfig + `
+resource "mongodb_role" "example" {
+  role = "test-role"
+  db   = "testdb-roleresource"
+  privileges = [
+    {
+      resource = { db = "testdb-roleresource", collection = "" }
+      actions  = ["insert", "update"]
+    },
+  ]
+  roles = [
+    { role = "read" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_role.example", "id", "testdb-roleresource.test-role"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.0.resource.db", "testdb-roleresource"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.0.resource.collection", ""),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.0.actions.#", "2"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.0.actions.0", "insert"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.0.actions.1", "update"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "roles.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "roles.0.role", "read"),
+				),
+			},
+			// Clear privileges and roles
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "example" {
+  role       = "test-role"
+  db         = "testdb-roleresource"
+  privileges = []
+  roles      = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_role.example", "id", "testdb-roleresource.test-role"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.#", "0"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "roles.#", "0"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAccRoleResourceAuthenticationRestrictions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "example" {
+  db   = "testdb-roleresource"
+  role = "test-restricted-role"
+  authentication_restrictions = [
+    { client_source = ["10.0.0.0/16"] },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_role.example", "authentication_restrictions.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "authentication_restrictions.0.client_source.#", "1"),
+				),
+			},
+			// Clear the restrictions
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "example" {
+  db   = "testdb-roleresource"
+  role = "test-restricted-role"
+  authentication_restrictions = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_role.example", "authentication_restrictions.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -5,10 +5,43 @@
 package provider
 
 import (
+	"regexp"
+
 	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// roleRefGrantAttributesSchema is the schema of a single role that is granted
+// by a grant resource. Changing the role replaces the grant. The role name
+// may not contain slashes, as it would make the grant ID ambiguous.
+var roleRefGrantAttributesSchema = map[string]schema.Attribute{
+	"role": schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Role name. Must not contain slashes (`/`).",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+			stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]*$`),
+				`Role names granted by grant resources cannot contain slashes, as the slash separates the grantee and the role in the resource ID.`),
+		},
+	},
+	"db": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Database this role belongs to. Leave unset to target same database as the grantee.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: databaseValidators,
+	},
+}
+
 type RoleRefResourceModel struct {
 	Role types.String `tfsdk:"role"`
 	DB   types.String `tfsdk:"db"`
@@ -35,11 +68,40 @@ func fromTypesRoleRefResourceSlice(roles []RoleRefResourceModel) []mongodb.RoleR
 func toTypesRoleRefResourceSlice(oldRoles []RoleRefResourceModel, roles []mongodb.RoleDBRef) []RoleRefResourceModel {
 	result := make([]RoleRefResourceModel, len(roles))
 	for i, role := range roles {
-		result[i] = toTypesRoleRefResource(oldRoles[i], role)
+		result[i] = toTypesRoleRefResource(findRoleRefResource(oldRoles, role), role)
 	}
 	return result
 }
 
+// findRoleRefResource returns the old role that refers to the given role,
+// matching on the name as the database may be left unset. Roles that were
+// not in the old model, such as roles granted outside of the resource,
+// keep their database.
+func findRoleRefResource(oldRoles []RoleRefResourceModel, role mongodb.RoleDBRef) RoleRefResourceModel {
+	for _, oldRole := range oldRoles {
+		if oldRole.Role.ValueString() == role.Role && oldRole.DB.ValueString() == role.DB {
+			return oldRole
+		}
+	}
+	for _, oldRole := range oldRoles {
+		if oldRole.Role.ValueString() == role.Role && oldRole.DB.IsNull() {
+			return oldRole
+		}
+	}
+	return RoleRefResourceModel{DB: types.StringValue(role.DB)}
+}
+
+// equalRoleRef returns true if the role reference points to the given role,
+// where an unset database refers to the database of the user or role that
+// the reference belongs to.
+func (r RoleRefResourceModel) equalRoleRef(ownerDB string, role mongodb.RoleDBRef) bool {
+	db := r.DB.ValueString()
+	if r.DB.IsNull() {
+		db = ownerDB
+	}
+	return r.Role.ValueString() == role.Role && db == role.DB
+}
+
 func toTypesRoleRefResource(oldRole RoleRefResourceModel, role mongodb.RoleDBRef) RoleRefResourceModel {
 	newRole := RoleRefResourceModel{
 		Role: types.StringValue(role.Role),
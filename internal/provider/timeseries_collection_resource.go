@@ -0,0 +1,490 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TimeseriesCollectionResource{}
+var _ resource.ResourceWithConfigure = &TimeseriesCollectionResource{}
+var _ resource.ResourceWithImportState = &TimeseriesCollectionResource{}
+var _ resource.ResourceWithValidateConfig = &TimeseriesCollectionResource{}
+
+func NewTimeseriesCollectionResource() resource.Resource {
+	return &TimeseriesCollectionResource{}
+}
+
+// TimeseriesCollectionResource defines the resource implementation.
+type TimeseriesCollectionResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// TimeseriesCollectionResourceModel describes the resource data model.
+type TimeseriesCollectionResourceModel struct {
+	ID                    types.String   `tfsdk:"id"`
+	DB                    types.String   `tfsdk:"db"`
+	Name                  types.String   `tfsdk:"name"`
+	TimeField             types.String   `tfsdk:"time_field"`
+	MetaField             types.String   `tfsdk:"meta_field"`
+	Granularity           types.String   `tfsdk:"granularity"`
+	BucketMaxSpanSeconds  types.Int64    `tfsdk:"bucket_max_span_seconds"`
+	BucketRoundingSeconds types.Int64    `tfsdk:"bucket_rounding_seconds"`
+	ExpireAfterSeconds    types.Int64    `tfsdk:"expire_after_seconds"`
+	BucketsCollection     types.String   `tfsdk:"buckets_collection"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (c TimeseriesCollectionResourceModel) collectionAndDB() (string, string, error) {
+	return collectionAndDB(c.ID, c.DB, c.Name)
+}
+
+func (c TimeseriesCollectionResourceModel) toNewCollection(collName string) mongodb.NewCollection {
+	return mongodb.NewCollection{
+		Name: collName,
+		CollectionOptions: mongodb.CollectionOptions{
+			Timeseries: &mongodb.TimeseriesOptions{
+				TimeField:             c.TimeField.ValueString(),
+				MetaField:             c.MetaField.ValueString(),
+				Granularity:           c.Granularity.ValueString(),
+				BucketMaxSpanSeconds:  c.BucketMaxSpanSeconds.ValueInt64Pointer(),
+				BucketRoundingSeconds: c.BucketRoundingSeconds.ValueInt64Pointer(),
+			},
+			ExpireAfterSeconds: c.ExpireAfterSeconds.ValueInt64Pointer(),
+		},
+	}
+}
+
+// toUpdateCollection returns the collMod command that changes the collection
+// from its prior state to the planned state. Only the changed fields are
+// included. All other attributes require replacing the collection.
+func (c TimeseriesCollectionResourceModel) toUpdateCollection(collName string, state TimeseriesCollectionResourceModel) mongodb.UpdateCollection {
+	update := mongodb.UpdateCollection{Name: collName}
+	if !c.ExpireAfterSeconds.Equal(state.ExpireAfterSeconds) {
+		if c.ExpireAfterSeconds.IsNull() {
+			update.ExpireAfterSeconds = mongodb.ExpireAfterSecondsOff
+		} else {
+			update.ExpireAfterSeconds = c.ExpireAfterSeconds.ValueInt64()
+		}
+	}
+	if !c.Granularity.IsUnknown() && !c.Granularity.Equal(state.Granularity) {
+		update.Timeseries = &mongodb.UpdateTimeseries{Granularity: c.Granularity.ValueString()}
+	}
+	return update
+}
+
+func (c *TimeseriesCollectionResourceModel) applyCollection(dbName string, coll mongodb.Collection) {
+	opts := coll.Options
+	c.ID = types.StringValue(dbName + "." + coll.Name)
+	c.DB = types.StringValue(dbName)
+	c.Name = types.StringValue(coll.Name)
+	c.BucketsCollection = types.StringValue(mongodb.SystemBucketsPrefix + coll.Name)
+	c.ExpireAfterSeconds = types.Int64PointerValue(opts.ExpireAfterSeconds)
+
+	ts := opts.Timeseries
+	if ts == nil {
+		ts = &mongodb.TimeseriesOptions{}
+	}
+	c.TimeField = types.StringValue(ts.TimeField)
+	c.MetaField = types.StringNull()
+	if ts.MetaField != "" {
+		c.MetaField = types.StringValue(ts.MetaField)
+	}
+	// MongoDB also reports the bucket span derived from the granularity,
+	// so the custom bucketing is only read when no granularity is set.
+	if ts.Granularity != "" {
+		c.Granularity = types.StringValue(ts.Granularity)
+		c.BucketMaxSpanSeconds = types.Int64Null()
+		c.BucketRoundingSeconds = types.Int64Null()
+	} else {
+		c.Granularity = types.StringNull()
+		c.BucketMaxSpanSeconds = types.Int64PointerValue(ts.BucketMaxSpanSeconds)
+		c.BucketRoundingSeconds = types.Int64PointerValue(ts.BucketRoundingSeconds)
+	}
+}
+
+func (r *TimeseriesCollectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_timeseries_collection"
+}
+
+func (r *TimeseriesCollectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Time series collection resource.\n\n" +
+			"Stores sequences of measurements efficiently, grouped into buckets by time and the meta field. " +
+			"Making the granularity coarser and changing the expiry are updated in place. " +
+			"Changing any other option replaces the collection, which **permanently deletes all of its data**.\n" +
+			"See: <https://www.mongodb.com/docs/manual/core/timeseries-collections/>",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Collection unique ID in MongoDB. Is composed from the `db` and `name` fields.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"db": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Database this collection belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: databaseValidators,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the collection.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: collectionValidators,
+			},
+			"time_field": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the field that contains the date of each measurement.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"meta_field": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Name of the field that contains metadata identifying the source of the measurements, " +
+					"which rarely changes. Measurements are bucketed by this field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"granularity": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Expected interval between measurements with the same meta field value. " +
+					"One of `seconds`, `minutes`, or `hours`. Defaults to `seconds`, unless custom bucketing is used. " +
+					"Can be made coarser in place, but making it finer replaces the collection.",
+				PlanModifiers: []planmodifier.String{
+					granularityPlanModifier{},
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						if req.PlanValue.IsUnknown() || req.PlanValue.IsNull() {
+							return
+						}
+						// Changing from custom bucketing, or to a finer granularity,
+						// is not supported by collMod.
+						resp.RequiresReplace = req.StateValue.IsNull() ||
+							granularityIndex(req.PlanValue.ValueString()) < granularityIndex(req.StateValue.ValueString())
+					}, "Making the granularity finer replaces the collection.", "Making the granularity finer replaces the collection."),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(mongodb.Granularities...),
+					stringvalidator.ConflictsWith(path.MatchRoot("bucket_max_span_seconds")),
+				},
+			},
+			"bucket_max_span_seconds": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Custom maximum time span of the measurements in a bucket, in seconds. " +
+					"Must be equal to `bucket_rounding_seconds`. Requires MongoDB 6.3 or later.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 31536000),
+					int64validator.AlsoRequires(path.MatchRoot("bucket_rounding_seconds")),
+				},
+			},
+			"bucket_rounding_seconds": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Custom interval, in seconds, that the start time of new buckets is rounded down to. " +
+					"Must be equal to `bucket_max_span_seconds`. Requires MongoDB 6.3 or later.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 31536000),
+					int64validator.AlsoRequires(path.MatchRoot("bucket_max_span_seconds")),
+				},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Automatically delete measurements after this many seconds, " +
+					"based on the time in the `time_field`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"buckets_collection": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Name of the internal `system.buckets` collection that stores the measurements. " +
+					"Privileges on the raw buckets are granted using the `system_buckets` resource of `mongodb_role`, " +
+					"which is set to the `name` of the time series collection.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func granularityIndex(granularity string) int {
+	return slices.Index(mongodb.Granularities, granularity)
+}
+
+// granularityPlanModifier keeps the granularity from the state when it is
+// not configured, unless custom bucketing is configured, which MongoDB does
+// not allow to be combined with a granularity.
+type granularityPlanModifier struct{}
+
+var _ planmodifier.String = granularityPlanModifier{}
+
+func (granularityPlanModifier) Description(context.Context) string {
+	return "Keeps the granularity from the state, unless custom bucketing is configured."
+}
+
+func (m granularityPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (granularityPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Explicit granularity, or destroying the resource.
+	if !req.ConfigValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var bucketMaxSpanSeconds, bucketRoundingSeconds types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bucket_max_span_seconds"), &bucketMaxSpanSeconds)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bucket_rounding_seconds"), &bucketRoundingSeconds)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !bucketMaxSpanSeconds.IsNull() || !bucketRoundingSeconds.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+	if !req.StateValue.IsNull() {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (r *TimeseriesCollectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Only reading the validated attributes, as other attributes may be
+	// unknown, which cannot be read into the model.
+	var bucketMaxSpanSeconds, bucketRoundingSeconds types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bucket_max_span_seconds"), &bucketMaxSpanSeconds)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bucket_rounding_seconds"), &bucketRoundingSeconds)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if bucketMaxSpanSeconds.IsUnknown() || bucketRoundingSeconds.IsUnknown() {
+		return
+	}
+	if !bucketMaxSpanSeconds.Equal(bucketRoundingSeconds) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bucket_rounding_seconds"),
+			"Invalid Attribute Configuration",
+			"The bucket_rounding_seconds attribute must be equal to bucket_max_span_seconds.",
+		)
+	}
+}
+
+func (r *TimeseriesCollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *TimeseriesCollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *TimeseriesCollectionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.CreateDBCollection(ctx, dbName, data.toNewCollection(collName))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create time series collection", err)
+		return
+	}
+
+	data.applyCollection(dbName, coll)
+
+	tflog.Trace(ctx, "created time series collection")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TimeseriesCollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *TimeseriesCollectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.GetDBCollection(ctx, dbName, collName)
+	if mongodb.IsNotFound(err) {
+		// The collection was dropped outside of Terraform. Removing it from
+		// the state lets Terraform plan to create it again.
+		tflog.Warn(ctx, "time series collection not found, removing from state", map[string]any{
+			"db":         dbName,
+			"collection": collName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read time series collection", err)
+		return
+	}
+	if coll.Type != mongodb.CollectionTypeTimeseries {
+		resp.Diagnostics.AddError("Unexpected Collection Type",
+			fmt.Sprintf("Expected %s.%s to be a timeseries, but it is a %s.", dbName, collName, coll.Type))
+		return
+	}
+
+	data.applyCollection(dbName, coll)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TimeseriesCollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *TimeseriesCollectionResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.UpdateDBCollection(ctx, dbName, data.toUpdateCollection(collName, *state))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update time series collection", err)
+		return
+	}
+
+	data.applyCollection(dbName, coll)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TimeseriesCollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *TimeseriesCollectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	collName, dbName, err := data.collectionAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve collection and database, got error: %s", err))
+		return
+	}
+
+	// Dropping the time series collection also drops its buckets collection.
+	if err := r.client.DeleteDBCollection(ctx, dbName, collName); err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "drop time series collection", err)
+		return
+	}
+}
+
+func (r *TimeseriesCollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccTimeseriesCollectionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_timeseries_collection" "example" {
+  db                   = "testdb-timeseriesresource"
+  name                 = "weather"
+  time_field           = "timestamp"
+  meta_field           = "sensor"
+  expire_after_seconds = 86400
+}
+
+resource "mongodb_role" "example" {
+  db   = "testdb-timeseriesresource"
+  role = "weatherBucketsReader"
+  privileges = [
+    {
+      resource = { db = mongodb_timeseries_collection.example.db, system_buckets = mongodb_timeseries_collection.example.name }
+      actions  = ["find"]
+    },
+    {
+      resource = { db = "", system_buckets = mongodb_timeseries_collection.example.name }
+      actions  = ["find"]
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "id", "testdb-timeseriesresource.weather"),
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "granularity", "seconds"),
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "buckets_collection", "system.buckets.weather"),
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "expire_after_seconds", "86400"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.0.resource.system_buckets", "weather"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.1.resource.db", ""),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "mongodb_timeseries_collection.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update in place using collMod
+			{
+				Config: providerConfig + `
+resource "mongodb_timeseries_collection" "example" {
+  db                   = "testdb-timeseriesresource"
+  name                 = "weather"
+  time_field           = "timestamp"
+  meta_field           = "sensor"
+  granularity          = "minutes"
+  expire_after_seconds = 172800
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_timeseries_collection.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "granularity", "minutes"),
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "expire_after_seconds", "172800"),
+				),
+			},
+			// Making the granularity finer replaces the collection
+			{
+				Config: providerConfig + `
+resource "mongodb_timeseries_collection" "example" {
+  db          = "testdb-timeseriesresource"
+  name        = "weather"
+  time_field  = "timestamp"
+  meta_field  = "sensor"
+  granularity = "seconds"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_timeseries_collection.example", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "granularity", "seconds"),
+					resource.TestCheckNoResourceAttr("mongodb_timeseries_collection.example", "expire_after_seconds"),
+				),
+			},
+			// Switching to custom bucketing replaces the collection without a granularity
+			{
+				Config: providerConfig + `
+resource "mongodb_timeseries_collection" "example" {
+  db                      = "testdb-timeseriesresource"
+  name                    = "weather"
+  time_field              = "timestamp"
+  meta_field              = "sensor"
+  bucket_max_span_seconds = 7200
+  bucket_rounding_seconds = 7200
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_timeseries_collection.example", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_timeseries_collection.example", "granularity"),
+					resource.TestCheckResourceAttr("mongodb_timeseries_collection.example", "bucket_max_span_seconds", "7200"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
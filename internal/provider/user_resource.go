@@ -9,15 +9,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -29,6 +34,12 @@ import (
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithConfigure = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithValidateConfig = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+
+// customDataRotatedAtKey is the key in the custom data of a user where the
+// time of the last password rotation is recorded.
+const customDataRotatedAtKey = "terraformPasswordRotatedAt"
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -36,19 +47,37 @@ func NewUserResource() resource.Resource {
 
 // UserResource defines the resource implementation.
 type UserResource struct {
-	client *mongodb.Client
+	client   *mongodb.Client
+	timeouts defaultTimeouts
 }
 
 // UserResourceModel describes the resource data model.
 type UserResourceModel struct {
-	ID         types.String            `tfsdk:"id"`
-	User       types.String            `tfsdk:"user"`
-	DB         types.String            `tfsdk:"db"`
-	Password   types.String            `tfsdk:"pwd"`
-	CustomData map[string]types.String `tfsdk:"custom_data"`
-	Roles      []RoleRefResourceModel  `tfsdk:"roles"`
-	Mechanisms []types.String          `tfsdk:"mechanisms"`
-	Timeouts   timeouts.Value          `tfsdk:"timeouts"`
+	ID       types.String `tfsdk:"id"`
+	User     types.String `tfsdk:"user"`
+	DB       types.String `tfsdk:"db"`
+	Password types.String `tfsdk:"pwd"`
+	// PasswordWO is write-only, and is therefore always null in the plan and
+	// state. Its value is only available in the config.
+	PasswordWO        types.String                   `tfsdk:"pwd_wo"`
+	PasswordWOVersion types.Int64                    `tfsdk:"pwd_wo_version"`
+	PasswordDigest    types.String                   `tfsdk:"pwd_digest"`
+	ClientSideDigest  types.Bool                     `tfsdk:"client_side_digest"`
+	VerifyPassword    types.Bool                     `tfsdk:"verify_password"`
+	GeneratePassword  *GeneratePasswordResourceModel `tfsdk:"generate_password"`
+	CustomData        map[string]types.String        `tfsdk:"custom_data"`
+	Roles             []RoleRefResourceModel         `tfsdk:"roles"`
+	Mechanisms        []types.String                 `tfsdk:"mechanisms"`
+	Timeouts          timeouts.Value                 `tfsdk:"timeouts"`
+
+	AuthenticationRestrictions []AuthenticationRestrictionResourceModel `tfsdk:"authentication_restrictions"`
+
+	RotationPeriod        types.String `tfsdk:"rotation_period"`
+	RotationGracePeriod   types.String `tfsdk:"rotation_grace_period"`
+	RotationSecondaryUser types.String `tfsdk:"rotation_secondary_user"`
+	RotatedAt             types.String `tfsdk:"rotated_at"`
+	ActiveUser            types.String `tfsdk:"active_user"`
+	PreviousPwdExpiresAt  types.String `tfsdk:"previous_pwd_expires_at"`
 }
 
 func (u UserResourceModel) userAndDB() (string, string, error) {
@@ -81,6 +110,7 @@ func (u *UserResourceModel) applyUser(user mongodb.User) {
 	u.DB = types.StringValue(user.DB)
 	if u.CustomData != nil {
 		u.CustomData = toTypesStringMap(user.CustomData)
+		delete(u.CustomData, customDataRotatedAtKey)
 	}
 	if u.Roles != nil {
 		u.Roles = toTypesRoleRefResourceSlice(u.Roles, user.Roles)
@@ -88,6 +118,137 @@ func (u *UserResourceModel) applyUser(user mongodb.User) {
 	if u.Mechanisms != nil {
 		u.Mechanisms = toTypesStringSlice(user.Mechanisms)
 	}
+	if u.AuthenticationRestrictions != nil {
+		u.AuthenticationRestrictions = toTypesAuthenticationRestrictionResourceSlice(user.AuthenticationRestrictions)
+	}
+	// Imported users have no values for the attributes with defaults.
+	if u.VerifyPassword.IsNull() {
+		u.VerifyPassword = types.BoolValue(false)
+	}
+	if u.ClientSideDigest.IsNull() {
+		u.ClientSideDigest = types.BoolValue(false)
+	}
+	u.RotatedAt = types.StringNull()
+	if rotatedAt, ok := user.CustomData[customDataRotatedAtKey]; ok {
+		u.RotatedAt = types.StringValue(rotatedAt)
+	}
+	if u.RotationSecondaryUser.IsNull() || u.ActiveUser.IsNull() || u.ActiveUser.IsUnknown() {
+		u.ActiveUser = types.StringValue(user.User)
+	}
+	if u.RotationGracePeriod.IsNull() || u.PreviousPwdExpiresAt.IsUnknown() {
+		u.PreviousPwdExpiresAt = types.StringNull()
+	}
+}
+
+// customData returns the custom data of the user, including the time of
+// the last password rotation.
+func (u UserResourceModel) customData() map[string]string {
+	customData := fromTypesStringMap(u.CustomData)
+	if !u.RotatedAt.IsNull() && !u.RotatedAt.IsUnknown() {
+		customData[customDataRotatedAtKey] = u.RotatedAt.ValueString()
+	}
+	return customData
+}
+
+// resolveRotation records the time of the password rotation, if the
+// password was rotated as planned by [UserResource.ModifyPlan].
+func (u *UserResourceModel) resolveRotation(now time.Time) {
+	if u.RotationPeriod.IsNull() {
+		u.RotatedAt = types.StringNull()
+		return
+	}
+	if u.RotatedAt.IsUnknown() {
+		u.RotatedAt = types.StringValue(now.UTC().Format(time.RFC3339))
+	}
+}
+
+// resolvePassword generates a new password if the planned password is
+// unknown, as planned by [generatedPasswordPlanModifier]. The pwdWO is the
+// write-only password read from the config.
+func (u *UserResourceModel) resolvePassword(dbName string, pwdWO types.String) error {
+	if !u.Password.IsUnknown() {
+		return nil
+	}
+	if dbName == mongodb.DBExternal || !pwdWO.IsNull() || !u.PasswordDigest.IsNull() {
+		u.Password = types.StringNull()
+		return nil
+	}
+	pwd, err := u.GeneratePassword.generate()
+	if err != nil {
+		return err
+	}
+	u.Password = types.StringValue(pwd)
+	return nil
+}
+
+// passwordDigest returns where the password is digested.
+func (u UserResourceModel) passwordDigest() mongodb.PasswordDigest {
+	switch {
+	case !u.PasswordDigest.IsNull():
+		return mongodb.DigestPrecomputed
+	case u.ClientSideDigest.ValueBool():
+		return mongodb.DigestClient
+	default:
+		return mongodb.DigestServer
+	}
+}
+
+// toNewUser returns the createUser command for the user, or for its
+// secondary user, which shares all the settings except the password.
+func (u UserResourceModel) toNewUser(userName, pwd string) mongodb.NewUser {
+	return mongodb.NewUser{
+		User:       userName,
+		Password:   pwd,
+		CustomData: u.customData(),
+		Roles:      fromTypesRoleRefResourceSlice(u.Roles),
+		Mechanisms: fromTypesStringSlice[mongodb.Mechanism](u.Mechanisms),
+		Digest:     u.passwordDigest(),
+
+		AuthenticationRestrictions: fromTypesAuthenticationRestrictionResourceSlice(u.AuthenticationRestrictions),
+	}
+}
+
+// toUpdateUser returns the updateUser command that changes the user from its
+// prior state to the planned state. Only the changed fields are included.
+// The pwdWO is the write-only password read from the config.
+func (u UserResourceModel) toUpdateUser(userName string, state UserResourceModel, pwdWO types.String) mongodb.UpdateUser {
+	update := mongodb.UpdateUser{User: userName, Digest: u.passwordDigest()}
+	switch {
+	case !u.Password.IsNull():
+		if !u.Password.Equal(state.Password) {
+			pwd := u.Password.ValueString()
+			update.Password = &pwd
+		}
+	case !pwdWO.IsNull():
+		// The write-only password is never stored, so there is no prior
+		// value to compare with. Only update it when the version changes.
+		if !u.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+			pwd := pwdWO.ValueString()
+			update.Password = &pwd
+		}
+	case !u.PasswordDigest.IsNull():
+		if !u.PasswordDigest.Equal(state.PasswordDigest) {
+			pwd := u.PasswordDigest.ValueString()
+			update.Password = &pwd
+		}
+	}
+	if !equalTypesStringMap(u.CustomData, state.CustomData) || !u.RotatedAt.Equal(state.RotatedAt) {
+		customData := u.customData()
+		update.CustomData = &customData
+	}
+	if !equalUnordered(u.Roles, state.Roles) {
+		roles := fromTypesRoleRefResourceSlice(u.Roles)
+		update.Roles = &roles
+	}
+	if !equalUnordered(u.Mechanisms, state.Mechanisms) {
+		mechanisms := fromTypesStringSlice[mongodb.Mechanism](u.Mechanisms)
+		update.Mechanisms = &mechanisms
+	}
+	if !equalUnorderedFunc(u.AuthenticationRestrictions, state.AuthenticationRestrictions, AuthenticationRestrictionResourceModel.Equal) {
+		restrictions := fromTypesAuthenticationRestrictionResourceSlice(u.AuthenticationRestrictions)
+		update.AuthenticationRestrictions = &restrictions
+	}
+	return update
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -129,29 +290,112 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					"  - Cannot create users in the `local` database.\n" +
 					"  - Cannot be empty.\n" +
 					"  - Cannot be longer than 64 characters.\n\n" +
+					"  Users authenticated by an external source, such as X.509 certificates, LDAP, Kerberos, " +
+					"OIDC, or AWS IAM, are created in the virtual `$external` database.\n\n" +
 					"  See documentation:\n\n" +
 					"  - <https://www.mongodb.com/docs/manual/reference/command/createUser/#local-database>\n" +
+					"  - <https://www.mongodb.com/docs/manual/reference/command/createUser/#external-credentials>\n" +
 					"  - <https://www.mongodb.com/docs/v6.0/reference/limits/#naming-restrictions>",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
-				Validators: append(
-					databaseValidators,
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.All(databaseValidators...),
+						stringvalidator.OneOf(mongodb.DBExternal),
+					),
 					stringvalidator.NoneOf("local"),
-				),
+				},
 			},
 			"pwd": schema.StringAttribute{
-				Required:            true,
-				Sensitive:           true,
-				MarkdownDescription: "Password of this user.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				MarkdownDescription: "Password of this user. Must not be set for users in the `$external` database.\n\n" +
+					"  When none of this, `pwd_wo`, or `pwd_digest` is set, a random password is generated, " +
+					"which can be configured using the `generate_password` attribute.\n\n" +
+					"  The password is stored in plaintext in the Terraform state. Use `pwd_wo` to avoid this.",
+				PlanModifiers: []planmodifier.String{
+					generatedPasswordPlanModifier{},
+				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("pwd_wo")),
+				},
+			},
+			"pwd_wo": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				MarkdownDescription: "Write-only password of this user, which is never stored in the Terraform state. " +
+					"Requires Terraform 1.11 or later.\n\n" +
+					"  As the password is not stored, changes to it are not detected. " +
+					"Change `pwd_wo_version` to update the password of the user.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("pwd")),
+				},
+			},
+			"pwd_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of the `pwd_wo` attribute. The password of the user is only updated when this value changes.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("pwd_wo")),
 				},
 			},
+			"pwd_digest": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "Precomputed SCRAM-SHA-1 password digest of this user, used instead of a password, " +
+					"so the plaintext password is never handled by Terraform nor sent to the server. " +
+					"The digest is the hex-encoded MD5 hash of `<user>:mongo:<password>`. " +
+					"Requires `mechanisms` to be set to `[\"SCRAM-SHA-1\"]`.\n\n" +
+					"  The digest is equivalent to the password for SCRAM-SHA-1 authentication, " +
+					"and is stored in plaintext in the Terraform state.\n\n" +
+					"  SCRAM-SHA-256 is not supported, and neither are precomputed SCRAM credentials " +
+					"(salt, stored key, and server key), as MongoDB derives SCRAM-SHA-256 credentials from the " +
+					"plaintext password and no user management command accepts precomputed credentials.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-f]{32}$`),
+						"must be a hex-encoded MD5 hash, in lowercase"),
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("pwd"),
+						path.MatchRoot("pwd_wo"),
+						path.MatchRoot("generate_password"),
+						path.MatchRoot("rotation_period"),
+					),
+				},
+			},
+			"client_side_digest": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Digest the password in the provider, so the plaintext password is never sent to the server. " +
+					"The provider sends the hex-encoded MD5 hash of `<user>:mongo:<password>`, " +
+					"which is equivalent to the password for SCRAM-SHA-1 authentication. " +
+					"Requires `mechanisms` to be set to `[\"SCRAM-SHA-1\"]`, as MongoDB derives the " +
+					"SCRAM-SHA-256 credentials from the plaintext password, so SCRAM-SHA-256 is not supported. " +
+					"Defaults to `false`.",
+			},
+			"generate_password": generatePasswordResourceAttributeSchema,
+			"verify_password": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Verify the `pwd` attribute when refreshing, by authenticating as the user " +
+					"on a separate connection. If the authentication fails, such as when the password was changed " +
+					"outside of Terraform, the password is updated on the next apply. Defaults to `false`.\n\n" +
+					"  Cannot be used with the `pwd_wo` attribute, as its value is not available when refreshing. " +
+					"Users with `authentication_restrictions` that do not allow connecting from the provider " +
+					"will always have their password updated.",
+			},
 			"custom_data": schema.MapAttribute{
 				Optional:            true,
 				MarkdownDescription: "Any custom data for this user. Map of string key and values of arbitrary values.",
 				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOf(customDataRotatedAtKey)),
+				},
 			},
 			"roles": schema.SetNestedAttribute{
 				Optional:            true,
@@ -186,28 +430,355 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					),
 				},
 			},
+			"authentication_restrictions": authenticationRestrictionResourceAttributeSchema,
+			"rotation_period": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Rotate the generated password when this duration has passed since the last rotation, " +
+					"such as `2160h` for 90 days. The rotation is planned on the first apply after the period has passed.\n\n" +
+					"  The time of the last rotation is recorded in the custom data of the user, " +
+					"with the `" + customDataRotatedAtKey + "` key.",
+				Validators: []validator.String{
+					durationValidator{},
+					stringvalidator.ConflictsWith(path.MatchRoot("pwd"), path.MatchRoot("pwd_wo")),
+				},
+			},
+			"rotation_secondary_user": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Name of a paired user in the same database, with the same roles and settings as this user. " +
+					"When set, each rotation sets the new password on the user that is not currently active, " +
+					"so the previous credentials of the `active_user` stay valid. " +
+					"Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 7*1000*1000),
+					stringvalidator.AlsoRequires(path.MatchRoot("rotation_period")),
+				},
+			},
+			"rotation_grace_period": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How long the previous credentials stay valid after a rotation. " +
+					"The previous password is revoked on the first apply after the grace period has passed. " +
+					"Must not be longer than `rotation_period`. " +
+					"When unset, the previous credentials stay valid until the next rotation.",
+				Validators: []validator.String{
+					durationValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("rotation_secondary_user")),
+				},
+			},
+			"rotated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time of the last password rotation, in RFC 3339 format. Only set when `rotation_period` is set.",
+			},
+			"active_user": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Name of the user that has the current password. " +
+					"This is either `user` or `rotation_secondary_user`, and should be used together with `pwd`.",
+			},
+			"previous_pwd_expires_at": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Time when the previous password is revoked, in RFC 3339 format. " +
+					"Only set when `rotation_grace_period` is set, and the previous password is still valid.",
+			},
 			"timeouts": timeouts.AttributesAll(ctx),
 		},
 	}
 }
 
+func (r *UserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Only reading the validated attributes, as the sets may be unknown,
+	// which cannot be read into the model.
+	var db, user, pwd, pwdWO types.String
+	var mechanisms types.Set
+	var verifyPassword, clientSideDigest types.Bool
+	var pwdDigest types.String
+	var generatePassword types.Object
+	var rotationPeriod, rotationGracePeriod, rotationSecondaryUser types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("db"), &db)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user"), &user)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pwd"), &pwd)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pwd_wo"), &pwdWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("mechanisms"), &mechanisms)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("verify_password"), &verifyPassword)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_side_digest"), &clientSideDigest)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pwd_digest"), &pwdDigest)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("generate_password"), &generatePassword)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rotation_period"), &rotationPeriod)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rotation_grace_period"), &rotationGracePeriod)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rotation_secondary_user"), &rotationSecondaryUser)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !user.IsUnknown() && !rotationSecondaryUser.IsNull() && user.Equal(rotationSecondaryUser) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotation_secondary_user"),
+			"Invalid Attribute Configuration",
+			"The rotation_secondary_user attribute must be different from the user attribute.",
+		)
+	}
+	if !rotationPeriod.IsNull() && !rotationPeriod.IsUnknown() &&
+		!rotationGracePeriod.IsNull() && !rotationGracePeriod.IsUnknown() {
+		period, periodErr := time.ParseDuration(rotationPeriod.ValueString())
+		gracePeriod, graceErr := time.ParseDuration(rotationGracePeriod.ValueString())
+		// Invalid durations are reported by the attribute validators.
+		if periodErr == nil && graceErr == nil && gracePeriod > period {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rotation_grace_period"),
+				"Invalid Attribute Configuration",
+				"The rotation_grace_period attribute must not be longer than rotation_period.",
+			)
+		}
+	}
+
+	if (clientSideDigest.ValueBool() || !pwdDigest.IsNull()) && !isOnlySCRAMSHA1(ctx, mechanisms) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mechanisms"),
+			"Invalid Attribute Configuration",
+			fmt.Sprintf("The mechanisms attribute must only contain %q when the password is digested on the client, "+
+				"using the client_side_digest or pwd_digest attributes.", mongodb.MechanismSCRAMSHA1),
+		)
+	}
+	if verifyPassword.ValueBool() && !pwdWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("verify_password"),
+			"Invalid Attribute Combination",
+			"The verify_password attribute cannot be enabled together with pwd_wo, "+
+				"as the write-only password is not available when refreshing.",
+		)
+	}
+	if !generatePassword.IsNull() && !generatePassword.IsUnknown() {
+		r.validateGeneratePasswordConfig(ctx, req, resp)
+	}
+
+	if db.IsUnknown() || db.IsNull() {
+		return
+	}
+	if db.ValueString() != mongodb.DBExternal {
+		return
+	}
+
+	// The credentials of external users are managed by the external source.
+	if !pwd.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pwd"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The pwd attribute must not be set for users in the %s database.", mongodb.DBExternal),
+		)
+	}
+	if !pwdDigest.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pwd_digest"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The pwd_digest attribute must not be set for users in the %s database.", mongodb.DBExternal),
+		)
+	}
+	if !pwdWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pwd_wo"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The pwd_wo attribute must not be set for users in the %s database.", mongodb.DBExternal),
+		)
+	}
+	if !rotationPeriod.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotation_period"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The rotation_period attribute must not be set for users in the %s database.", mongodb.DBExternal),
+		)
+	}
+	if !generatePassword.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("generate_password"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The generate_password attribute must not be set for users in the %s database.", mongodb.DBExternal),
+		)
+	}
+	if !mechanisms.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mechanisms"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The mechanisms attribute only applies to SCRAM users, and must not be set for users in the %s database.", mongodb.DBExternal),
+		)
+	}
+	// X.509 and LDAP users are distinguished names, while OIDC and AWS
+	// users are principal names or ARNs, which do not contain "=".
+	if !user.IsUnknown() && strings.Contains(user.ValueString(), "=") {
+		if err := validateDistinguishedName(user.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("user"),
+				"Invalid Distinguished Name",
+				fmt.Sprintf("The user attribute must be a distinguished name in the RFC 2253 format, "+
+					"such as \"CN=myName,OU=myOrgUnit,O=myOrg\", got error: %s", err),
+			)
+		}
+	}
+}
+
+// isOnlySCRAMSHA1 returns true if the mechanisms only contain SCRAM-SHA-1,
+// or if they are not yet known.
+func isOnlySCRAMSHA1(ctx context.Context, mechanisms types.Set) bool {
+	if mechanisms.IsUnknown() {
+		return true
+	}
+	if mechanisms.IsNull() {
+		return false
+	}
+	var values []types.String
+	if diags := mechanisms.ElementsAs(ctx, &values, false); diags.HasError() {
+		return true
+	}
+	for _, value := range values {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return len(values) == 1 && values[0].ValueString() == string(mongodb.MechanismSCRAMSHA1)
+}
+
+// validateGeneratePasswordConfig validates that the generated password
+// has at least one character class enabled.
+func (r *UserResource) validateGeneratePasswordConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var lower, upper, numeric, special types.Bool
+	options := path.Root("generate_password")
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, options.AtName("lower"), &lower)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, options.AtName("upper"), &upper)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, options.AtName("numeric"), &numeric)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, options.AtName("special"), &special)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unset classes use their defaults, where only special is disabled.
+	disabled := types.BoolValue(false)
+	if lower.Equal(disabled) && upper.Equal(disabled) && numeric.Equal(disabled) &&
+		(special.IsNull() || special.Equal(disabled)) {
+		resp.Diagnostics.AddAttributeError(
+			options,
+			"Invalid Attribute Configuration",
+			"At least one of the lower, upper, numeric, or special character classes must be enabled.",
+		)
+	}
+}
+
+// ModifyPlan plans the rotation of generated passwords, when the
+// rotation_period has passed since the last rotation, and the revocation of
+// the previous password, when the rotation_grace_period has passed.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to rotate when creating or destroying the resource, where
+	// the computed attributes are resolved by Create.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var user, pwd, rotationPeriod, gracePeriod, secondaryUser types.String
+	var rotatedAt, activeUser, previousPwdExpiresAt types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("user"), &user)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pwd"), &pwd)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotation_period"), &rotationPeriod)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotation_grace_period"), &gracePeriod)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotation_secondary_user"), &secondaryUser)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rotated_at"), &rotatedAt)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("active_user"), &activeUser)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("previous_pwd_expires_at"), &previousPwdExpiresAt)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if rotationPeriod.IsUnknown() || gracePeriod.IsUnknown() || secondaryUser.IsUnknown() {
+		return
+	}
+
+	if rotationPeriod.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotated_at"), types.StringNull())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active_user"), user)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("previous_pwd_expires_at"), types.StringNull())...)
+		return
+	}
+
+	now := time.Now()
+	if isRotationDue(rotatedAt, rotationPeriod, now) && !pwd.IsUnknown() {
+		tflog.Info(ctx, "rotation period has passed, planning password rotation", map[string]any{
+			"user":       user.ValueString(),
+			"rotated_at": rotatedAt.ValueString(),
+		})
+		pwd = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pwd"), pwd)...)
+	}
+
+	if pwd.IsUnknown() {
+		// Rotating, either because it is due or because a new password
+		// is generated for other reasons, such as changed keepers.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotated_at"), types.StringUnknown())...)
+		if secondaryUser.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active_user"), user)...)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active_user"), types.StringUnknown())...)
+		}
+		if gracePeriod.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("previous_pwd_expires_at"), types.StringNull())...)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("previous_pwd_expires_at"), types.StringUnknown())...)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotated_at"), rotatedAt)...)
+	if secondaryUser.IsNull() || activeUser.IsNull() {
+		activeUser = user
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active_user"), activeUser)...)
+	// Clearing the expiry plans an update, where the previous password is revoked.
+	if gracePeriod.IsNull() || isExpired(previousPwdExpiresAt, now) {
+		previousPwdExpiresAt = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("previous_pwd_expires_at"), previousPwdExpiresAt)...)
+}
+
+// isRotationDue returns true if the rotation period has passed since the
+// last rotation, or if the time of the last rotation is unknown.
+func isRotationDue(rotatedAt, rotationPeriod types.String, now time.Time) bool {
+	period, err := time.ParseDuration(rotationPeriod.ValueString())
+	if err != nil {
+		return false
+	}
+	if rotatedAt.IsNull() {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, rotatedAt.ValueString())
+	if err != nil {
+		return true
+	}
+	return !now.Before(last.Add(period))
+}
+
+// isExpired returns true if the timestamp is set and has passed.
+func isExpired(timestamp types.String, now time.Time) bool {
+	if timestamp.IsNull() || timestamp.IsUnknown() {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, timestamp.ValueString())
+	return err == nil && !now.Before(t)
+}
+
 func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*mongodb.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *mongodb.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.timeouts = data.timeouts
 }
 
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -219,7 +790,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	createTimeout, diags := data.Timeouts.Create(ctx, DefaultTimeout)
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -233,20 +804,51 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	user, err := r.client.CreateDBUser(ctx, dbName, mongodb.NewUser{
-		User:       userName,
-		Password:   data.Password.ValueString(),
-		CustomData: fromTypesStringMap(data.CustomData),
-		Roles:      fromTypesRoleRefResourceSlice(data.Roles),
-		Mechanisms: fromTypesStringSlice[mongodb.Mechanism](data.Mechanisms),
-	})
+	// Write-only attributes are only available in the config.
+	var pwdWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pwd_wo"), &pwdWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := data.resolvePassword(dbName, pwdWO); err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to generate password, got error: %s", err))
+		return
+	}
+	pwd := data.Password.ValueString()
+	if !pwdWO.IsNull() {
+		pwd = pwdWO.ValueString()
+	}
+	if !data.PasswordDigest.IsNull() {
+		pwd = data.PasswordDigest.ValueString()
+	}
+
+	data.resolveRotation(time.Now())
+
+	user, err := r.client.CreateDBUser(ctx, dbName, data.toNewUser(userName, pwd))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
+		addClientError(&resp.Diagnostics, "create user", err)
 		return
 	}
 
 	data.applyUser(user)
 
+	if !data.RotationSecondaryUser.IsNull() {
+		// The secondary user gets a password that is never used, until it
+		// becomes the active user on the first rotation.
+		secondaryPwd, err := data.GeneratePassword.generate()
+		if err != nil {
+			resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to generate password, got error: %s", err))
+		} else if _, err := r.client.CreateDBUser(ctx, dbName, data.toNewUser(data.RotationSecondaryUser.ValueString(), secondaryPwd)); err != nil {
+			addClientError(&resp.Diagnostics, "create secondary user", err)
+		}
+		if resp.Diagnostics.HasError() {
+			// Saving the created user, which taints the resource, so it is
+			// replaced on the next apply.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "created user")
@@ -264,7 +866,7 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	readTimeout, diags := data.Timeouts.Read(ctx, DefaultTimeout)
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -279,27 +881,56 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	user, err := r.client.GetDBUser(ctx, dbName, userName)
+	if mongodb.IsNotFound(err) {
+		// The user was deleted outside of Terraform. Removing it from the state
+		// lets Terraform plan to create it again.
+		tflog.Warn(ctx, "user not found, removing from state", map[string]any{
+			"db":   dbName,
+			"user": userName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read user", err)
 		return
 	}
 
 	data.applyUser(user)
 
+	if data.VerifyPassword.ValueBool() && !data.Password.IsNull() {
+		// With a secondary user, the current password belongs to the active user.
+		err := r.client.VerifyPassword(ctx, dbName, data.ActiveUser.ValueString(), data.Password.ValueString())
+		if errors.Is(err, mongodb.ErrAuthenticationFailed) {
+			// Clearing the password from the state makes Terraform plan
+			// to update it back to the configured password.
+			tflog.Warn(ctx, "user password verification failed, marking password as changed", map[string]any{
+				"db":    dbName,
+				"user":  userName,
+				"error": err.Error(),
+			})
+			data.Password = types.StringNull()
+		} else if err != nil {
+			addClientError(&resp.Diagnostics, "verify user password", err)
+			return
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *UserResourceModel
+	var data, state *UserResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	updateTimeout, diags := data.Timeouts.Update(ctx, DefaultTimeout)
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -313,15 +944,31 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	user, err := r.client.UpdateDBUser(ctx, dbName, mongodb.UpdateUser{
-		User:       userName,
-		Password:   data.Password.ValueString(),
-		CustomData: fromTypesStringMap(data.CustomData),
-		Roles:      fromTypesRoleRefResourceSlice(data.Roles),
-		Mechanisms: fromTypesStringSlice[mongodb.Mechanism](data.Mechanisms),
-	})
+	// Write-only attributes are only available in the config.
+	var pwdWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pwd_wo"), &pwdWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotated := data.Password.IsUnknown()
+	if err := data.resolvePassword(dbName, pwdWO); err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to generate password, got error: %s", err))
+		return
+	}
+	data.resolveRotation(time.Now())
+
+	update := data.toUpdateUser(userName, *state, pwdWO)
+	if !data.RotationSecondaryUser.IsNull() {
+		if err := r.updateSecondaryUser(ctx, dbName, data, *state, &update, rotated); err != nil {
+			addClientError(&resp.Diagnostics, "update secondary user", err)
+			return
+		}
+	}
+
+	user, err := r.client.UpdateDBUser(ctx, dbName, update)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user, got error: %s", err))
+		addClientError(&resp.Diagnostics, "update user", err)
 		return
 	}
 
@@ -331,6 +978,57 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// updateSecondaryUser applies the update of the user to its secondary user.
+// When rotating, the new password is set on the user that is not active,
+// which then becomes the active user. When the grace period of the
+// previous password has passed, it is revoked by replacing it with a
+// password that is never used.
+func (r *UserResource) updateSecondaryUser(ctx context.Context, dbName string, data *UserResourceModel, state UserResourceModel, update *mongodb.UpdateUser, rotated bool) error {
+	secondaryUpdate := *update
+	secondaryUpdate.User = data.RotationSecondaryUser.ValueString()
+	secondaryUpdate.Password = nil
+	update.Password = nil
+
+	activeUser := update.User
+	if !state.ActiveUser.IsNull() {
+		activeUser = state.ActiveUser.ValueString()
+	}
+	inactive := &secondaryUpdate
+	if activeUser == secondaryUpdate.User {
+		inactive = update
+	}
+
+	switch {
+	case rotated:
+		pwd := data.Password.ValueString()
+		inactive.Password = &pwd
+		activeUser = inactive.User
+		data.PreviousPwdExpiresAt = types.StringNull()
+		if !data.RotationGracePeriod.IsNull() {
+			gracePeriod, err := time.ParseDuration(data.RotationGracePeriod.ValueString())
+			if err != nil {
+				return fmt.Errorf("parse rotation_grace_period: %w", err)
+			}
+			expiresAt := time.Now().Add(gracePeriod).UTC().Format(time.RFC3339)
+			data.PreviousPwdExpiresAt = types.StringValue(expiresAt)
+		}
+	case !state.PreviousPwdExpiresAt.IsNull() && data.PreviousPwdExpiresAt.IsNull():
+		tflog.Info(ctx, "revoking previous password", map[string]any{
+			"db":   dbName,
+			"user": inactive.User,
+		})
+		pwd, err := data.GeneratePassword.generate()
+		if err != nil {
+			return fmt.Errorf("generate password: %w", err)
+		}
+		inactive.Password = &pwd
+	}
+	data.ActiveUser = types.StringValue(activeUser)
+
+	_, err := r.client.UpdateDBUser(ctx, dbName, secondaryUpdate)
+	return err
+}
+
 func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data *UserResourceModel
 
@@ -340,7 +1038,7 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	deleteTimeout, diags := data.Timeouts.Delete(ctx, DefaultTimeout)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -354,8 +1052,15 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	if err := r.client.DeleteDBUser(ctx, dbName, userName); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
+	if !data.RotationSecondaryUser.IsNull() {
+		if err := r.client.DeleteDBUser(ctx, dbName, data.RotationSecondaryUser.ValueString()); err != nil && !mongodb.IsNotFound(err) {
+			addClientError(&resp.Diagnostics, "delete secondary user", err)
+			return
+		}
+	}
+
+	if err := r.client.DeleteDBUser(ctx, dbName, userName); err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete user", err)
 		return
 	}
 }
@@ -5,9 +5,17 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccUserResource(t *testing.T) {
@@ -69,7 +77,528 @@ resource "mongodb_user" "test" {
 					resource.TestCheckResourceAttr("mongodb_user.test", "roles.0.role", "read"),
 				),
 			},
+			// Recreate after deleted outside of Terraform
+			{
+				PreConfig: func() {
+					deleteTestUser(t, "testdb-userresource", "test-user")
+				},
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user  = "test-user"
+  db    = "testdb-userresource"
+  pwd   = "secret1234"
+  roles = [
+    { role = "read" },
+  ]
+  custom_data = {
+    "my-custom-field" = "my-updated-custom-value"
+  }
+  mechanisms = [ "SCRAM-SHA-256" ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "id", "testdb-userresource.test-user"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "custom_data.%", "1"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "custom_data.my-custom-field", "my-updated-custom-value"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "roles.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "roles.0.role", "read"),
+				),
+			},
+			// Clear roles and custom_data
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user       = "test-user"
+  db         = "testdb-userresource"
+  pwd        = "secret1234"
+  roles      = []
+  mechanisms = [ "SCRAM-SHA-256" ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "id", "testdb-userresource.test-user"),
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "custom_data.%"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "roles.#", "0"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAccUserResourceAlreadyExists(t *testing.T) {
+	createTestUser(t, "testdb-userresource", "test-existing-user")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-existing-user"
+  db   = "testdb-userresource"
+  pwd  = "secret1234"
+}
+`,
+				ExpectError: regexp.MustCompile(`User Already Exists`),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceAuthenticationRestrictions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-restricted-user"
+  db   = "testdb-userresource"
+  pwd  = "secret1234"
+  authentication_restrictions = [
+    { client_source = ["10.0.0.0/16", "127.0.0.1"] },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "authentication_restrictions.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "authentication_restrictions.0.client_source.#", "2"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-restricted-user"
+  db   = "testdb-userresource"
+  pwd  = "secret1234"
+  authentication_restrictions = [
+    { client_source = ["10.0.0.0/16"], server_address = ["10.1.0.0/24"] },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "authentication_restrictions.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "authentication_restrictions.0.client_source.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "authentication_restrictions.0.server_address.#", "1"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-restricted-user"
+  db   = "testdb-userresource"
+  pwd  = "secret1234"
+  authentication_restrictions = [
+    { client_source = ["10.0.0.0/33"] },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid CIDR`),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceExternal(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "CN=test-client,OU=clients,O=example"
+  db   = "$external"
+  roles = [
+    { role = "read", db = "testdb-userresource" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "id", "$external.CN=test-client,OU=clients,O=example"),
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "pwd"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "CN=test-client, OU=clients, O=example"
+  db   = "$external"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Distinguished Name`),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "CN=test-client,OU=clients,O=example"
+  db   = "$external"
+  pwd  = "secret1234"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceWriteOnlyPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user           = "test-wo-user"
+  db             = "testdb-userresource"
+  pwd_wo         = "secret1234"
+  pwd_wo_version = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "pwd"),
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "pwd_wo"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "pwd_wo_version", "1"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user           = "test-wo-user"
+  db             = "testdb-userresource"
+  pwd_wo         = "secret5678"
+  pwd_wo_version = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "pwd_wo"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "pwd_wo_version", "2"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user           = "test-wo-user"
+  db             = "testdb-userresource"
+  pwd            = "secret1234"
+  pwd_wo         = "secret1234"
+  pwd_wo_version = 2
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceVerifyPassword(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_user" "test" {
+  user            = "test-verify-user"
+  db              = "testdb-userresource"
+  pwd             = "secret1234"
+  verify_password = true
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "verify_password", "true"),
+					testCheckUserPassword("testdb-userresource", "test-verify-user", "secret1234"),
+				),
+			},
+			{
+				// Password changed outside of Terraform is detected when refreshing
+				PreConfig: func() {
+					updateTestUserPassword(t, "testdb-userresource", "test-verify-user", "changed1234")
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  testCheckUserPassword("testdb-userresource", "test-verify-user", "secret1234"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user            = "test-verify-user"
+  db              = "testdb-userresource"
+  pwd_wo          = "secret1234"
+  verify_password = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testCheckUserPassword(dbName, userName, password string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+		return db.VerifyPassword(context.Background(), dbName, userName, password)
+	}
+}
+
+func TestAccUserResourceGeneratePassword(t *testing.T) {
+	var previousPwd string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-generated-user"
+  db   = "testdb-userresource"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("mongodb_user.test", "pwd", func(value string) error {
+						if len(value) != defaultGeneratedPasswordLength {
+							return fmt.Errorf("expected generated password of length %d, got %d", defaultGeneratedPasswordLength, len(value))
+						}
+						previousPwd = value
+						return nil
+					}),
+					testCheckUserStatePassword("mongodb_user.test"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-generated-user"
+  db   = "testdb-userresource"
+  generate_password = {
+    length  = 16
+    special = true
+    keepers = {
+      rotated = "2026-01-01"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("mongodb_user.test", "pwd", func(value string) error {
+						if len(value) != 16 {
+							return fmt.Errorf("expected generated password of length 16, got %d", len(value))
+						}
+						if value == previousPwd {
+							return fmt.Errorf("expected password to be regenerated")
+						}
+						previousPwd = value
+						return nil
+					}),
+					testCheckUserStatePassword("mongodb_user.test"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-generated-user"
+  db   = "testdb-userresource"
+  generate_password = {
+    length  = 16
+    special = true
+    keepers = {
+      rotated = "2026-02-01"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("mongodb_user.test", "pwd", func(value string) error {
+						if value == previousPwd {
+							return fmt.Errorf("expected password to be regenerated when keepers change")
+						}
+						return nil
+					}),
+					testCheckUserStatePassword("mongodb_user.test"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user = "test-generated-user"
+  db   = "testdb-userresource"
+  generate_password = {
+    lower   = false
+    upper   = false
+    numeric = false
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Configuration`),
+			},
+		},
+	})
+}
+
+// testCheckUserStatePassword checks that the active user can authenticate
+// using the password in the Terraform state.
+func testCheckUserStatePassword(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+		attrs := rs.Primary.Attributes
+		return testCheckUserPassword(attrs["db"], attrs["active_user"], attrs["pwd"])(s)
+	}
+}
+
+func TestAccUserResourceRotation(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_user" "test" {
+  user                    = "test-rotated-user"
+  db                      = "testdb-userresource"
+  rotation_period         = "2160h"
+  rotation_secondary_user = "test-rotated-user-secondary"
+  rotation_grace_period   = "10s"
+}
+`
+	var previousPwd string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mongodb_user.test", "rotated_at"),
+					resource.TestCheckResourceAttr("mongodb_user.test", "active_user", "test-rotated-user"),
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "previous_pwd_expires_at"),
+					resource.TestCheckResourceAttrWith("mongodb_user.test", "pwd", func(value string) error {
+						previousPwd = value
+						return nil
+					}),
+					testCheckUserStatePassword("mongodb_user.test"),
+				),
+			},
+			{
+				// Rotation is planned when the rotation period has passed
+				PreConfig: func() {
+					updateTestUserCustomData(t, "testdb-userresource", "test-rotated-user", map[string]string{
+						customDataRotatedAtKey: "2020-01-01T00:00:00Z",
+					})
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "active_user", "test-rotated-user-secondary"),
+					resource.TestCheckResourceAttrSet("mongodb_user.test", "previous_pwd_expires_at"),
+					resource.TestCheckResourceAttrWith("mongodb_user.test", "rotated_at", func(value string) error {
+						if value == "2020-01-01T00:00:00Z" {
+							return fmt.Errorf("expected rotated_at to be updated")
+						}
+						return nil
+					}),
+					testCheckUserStatePassword("mongodb_user.test"),
+					// Previous credentials are still valid during the grace period
+					func(s *terraform.State) error {
+						return testCheckUserPassword("testdb-userresource", "test-rotated-user", previousPwd)(s)
+					},
+				),
+			},
+			{
+				// Previous password is revoked when the grace period has passed
+				PreConfig: func() {
+					time.Sleep(11 * time.Second)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "active_user", "test-rotated-user-secondary"),
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "previous_pwd_expires_at"),
+					testCheckUserStatePassword("mongodb_user.test"),
+					func(s *terraform.State) error {
+						err := testCheckUserPassword("testdb-userresource", "test-rotated-user", previousPwd)(s)
+						if !errors.Is(err, mongodb.ErrAuthenticationFailed) {
+							return fmt.Errorf("expected previous password to be revoked, got: %v", err)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user            = "test-rotated-user"
+  db              = "testdb-userresource"
+  pwd             = "secret1234"
+  rotation_period = "2160h"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceClientSideDigest(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user               = "test-digest-user"
+  db                 = "testdb-userresource"
+  pwd                = "secret1234"
+  mechanisms         = ["SCRAM-SHA-1"]
+  client_side_digest = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.test", "client_side_digest", "true"),
+					testCheckUserPassword("testdb-userresource", "test-digest-user", "secret1234"),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "mongodb_user" "test" {
+  user       = "test-digest-user"
+  db         = "testdb-userresource"
+  pwd_digest = %q
+  mechanisms = ["SCRAM-SHA-1"]
+}
+`, mongodb.DigestPassword("test-digest-user", "secret5678")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_user.test", "pwd"),
+					testCheckUserPassword("testdb-userresource", "test-digest-user", "secret5678"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "test" {
+  user               = "test-digest-user"
+  db                 = "testdb-userresource"
+  pwd                = "secret1234"
+  client_side_digest = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Configuration`),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "mongodb_user" "test" {
+  user       = "test-digest-user"
+  db         = "testdb-userresource"
+  pwd_digest = %q
+  mechanisms = ["SCRAM-SHA-1", "SCRAM-SHA-256"]
+}
+`, mongodb.DigestPassword("test-digest-user", "secret5678")),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Configuration`),
+			},
+		},
+	})
+}
@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserRoleGrantResource{}
+var _ resource.ResourceWithConfigure = &UserRoleGrantResource{}
+var _ resource.ResourceWithImportState = &UserRoleGrantResource{}
+
+func NewUserRoleGrantResource() resource.Resource {
+	return &UserRoleGrantResource{}
+}
+
+// UserRoleGrantResource defines the resource implementation.
+type UserRoleGrantResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// UserRoleGrantResourceModel describes the resource data model.
+type UserRoleGrantResourceModel struct {
+	ID       types.String          `tfsdk:"id"`
+	User     types.String          `tfsdk:"user"`
+	DB       types.String          `tfsdk:"db"`
+	Role     *RoleRefResourceModel `tfsdk:"role"`
+	Timeouts timeouts.Value        `tfsdk:"timeouts"`
+}
+
+// grantID returns the ID of a grant in the format "db.name/roleDB.role",
+// where the first part is the ID of the user or role that is granted to.
+func grantID(db, name, roleDB, role string) string {
+	return db + "." + name + "/" + roleDB + "." + role
+}
+
+// parseGrantID parses an ID created by [grantID]. The grantee name may
+// contain both dots and slashes, but the role database and role name may not
+// contain slashes, which is ensured by [roleRefGrantAttributesSchema].
+func parseGrantID(id string) (db, name string, role RoleRefResourceModel, err error) {
+	if id == "" {
+		return "", "", RoleRefResourceModel{}, errors.New("missing grant ID")
+	}
+	i := strings.LastIndex(id, "/")
+	if i == -1 {
+		return "", "", RoleRefResourceModel{}, errors.New("malformed grant ID, missing slash separator on grantee and role")
+	}
+	db, name, ok := strings.Cut(id[:i], ".")
+	if !ok || db == "" || name == "" {
+		return "", "", RoleRefResourceModel{}, errors.New("malformed grant ID, grantee must be in the format db.name")
+	}
+	roleDB, roleName, ok := strings.Cut(id[i+1:], ".")
+	if !ok || roleDB == "" || roleName == "" {
+		return "", "", RoleRefResourceModel{}, errors.New("malformed grant ID, role must be in the format db.role")
+	}
+	return db, name, RoleRefResourceModel{
+		Role: types.StringValue(roleName),
+		DB:   types.StringValue(roleDB),
+	}, nil
+}
+
+// userAndDB returns the user and database names, either from the attributes
+// or from an imported ID. The role is also set from an imported ID.
+func (g *UserRoleGrantResourceModel) userAndDB() (string, string, error) {
+	if !g.User.IsNull() && !g.DB.IsNull() && g.Role != nil {
+		user := g.User.ValueString()
+		db := g.DB.ValueString()
+		if user != "" && db != "" {
+			return user, db, nil
+		}
+	}
+	db, user, role, err := parseGrantID(g.ID.ValueString())
+	if err != nil {
+		return "", "", err
+	}
+	g.Role = &role
+	return user, db, nil
+}
+
+func (g *UserRoleGrantResourceModel) applyUser(user mongodb.User) {
+	roleDB := g.Role.DB.ValueString()
+	if g.Role.DB.IsNull() {
+		roleDB = user.DB
+	}
+	g.ID = types.StringValue(grantID(user.DB, user.User, roleDB, g.Role.Role.ValueString()))
+	g.User = types.StringValue(user.User)
+	g.DB = types.StringValue(user.DB)
+}
+
+// hasRole returns true if the user has the granted role. All other roles of
+// the user are ignored, as they may be managed elsewhere.
+func (g UserRoleGrantResourceModel) hasRole(user mongodb.User) bool {
+	return slices.ContainsFunc(user.Roles, func(role mongodb.RoleDBRef) bool {
+		return g.Role.equalRoleRef(user.DB, role)
+	})
+}
+
+func (r *UserRoleGrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_role_grant"
+}
+
+func (r *UserRoleGrantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "User role grant resource.\n\n" +
+			"Grants a single role to an existing user, without affecting the other roles of the user. " +
+			"This allows multiple Terraform configurations to grant roles to the same user, " +
+			"including users that are not managed by Terraform.\n\n" +
+			"Do not combine with the `roles` attribute of a `mongodb_user` resource for the same user, " +
+			"as that attribute revokes all roles that it does not list.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Grant unique ID. Is composed from the user's `db` and `user` fields, " +
+					"and the role's `db` and `role` fields, in the format `db.user/roleDB.role`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the user to grant the role to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"db": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Database of the user to grant the role to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: databaseValidators,
+			},
+			"role": schema.SingleNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Role to grant to the user.",
+				Attributes:          roleRefGrantAttributesSchema,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *UserRoleGrantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *UserRoleGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *UserRoleGrantResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	userName, dbName, err := data.userAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve user and database, got error: %s", err))
+		return
+	}
+
+	user, err := r.client.GrantRolesToUser(ctx, dbName, userName, []mongodb.RoleRef{data.Role.toRoleRef()})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "grant role to user", err)
+		return
+	}
+
+	data.applyUser(user)
+
+	tflog.Trace(ctx, "granted role to user")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserRoleGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *UserRoleGrantResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	userName, dbName, err := data.userAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve user and database, got error: %s", err))
+		return
+	}
+
+	user, err := r.client.GetDBUser(ctx, dbName, userName)
+	if mongodb.IsNotFound(err) {
+		tflog.Warn(ctx, "user not found, removing role grant from state", map[string]any{
+			"db":   dbName,
+			"user": userName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read user", err)
+		return
+	}
+	if !data.hasRole(user) {
+		// The role was revoked outside of Terraform. Removing it from the
+		// state lets Terraform plan to grant it again.
+		tflog.Warn(ctx, "role not granted to user, removing role grant from state", map[string]any{
+			"db":   dbName,
+			"user": userName,
+			"role": data.Role.Role.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.applyUser(user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserRoleGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *UserRoleGrantResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All attributes other than the timeouts require replacing the grant,
+	// so there is nothing to update in MongoDB.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserRoleGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *UserRoleGrantResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	userName, dbName, err := data.userAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve user and database, got error: %s", err))
+		return
+	}
+
+	// The grant is already gone if either the user or the role was deleted.
+	err = r.client.RevokeRolesFromUser(ctx, dbName, userName, []mongodb.RoleRef{data.Role.toRoleRef()})
+	if err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "revoke role from user", err)
+		return
+	}
+}
+
+func (r *UserRoleGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
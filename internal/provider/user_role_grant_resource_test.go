@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccUserRoleGrantResource(t *testing.T) {
+	// The user is managed outside of Terraform, and already has the
+	// "readWrite" role, which must be left alone.
+	createTestUser(t, "testdb-userrolegrant", "test-user")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testCheckUserRoles("testdb-userrolegrant", "test-user", []mongodb.RoleDBRef{
+			{Role: "readWrite", DB: "testdb-userrolegrant"},
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_user_role_grant" "same_db" {
+  user = "test-user"
+  db   = "testdb-userrolegrant"
+  role = { role = "dbAdmin" }
+}
+
+resource "mongodb_user_role_grant" "other_db" {
+  user = "test-user"
+  db   = "testdb-userrolegrant"
+  role = { role = "read", db = "testdb-userrolegrant-other" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user_role_grant.same_db", "id", "testdb-userrolegrant.test-user/testdb-userrolegrant.dbAdmin"),
+					resource.TestCheckResourceAttr("mongodb_user_role_grant.other_db", "id", "testdb-userrolegrant.test-user/testdb-userrolegrant-other.read"),
+					testCheckUserRoles("testdb-userrolegrant", "test-user", []mongodb.RoleDBRef{
+						{Role: "readWrite", DB: "testdb-userrolegrant"},
+						{Role: "dbAdmin", DB: "testdb-userrolegrant"},
+						{Role: "read", DB: "testdb-userrolegrant-other"},
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "mongodb_user_role_grant.other_db",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testCheckUserRoles checks that the user has exactly the given roles,
+// in any order.
+func testCheckUserRoles(dbName, userName string, roles []mongodb.RoleDBRef) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		db := mongodb.New(mongodbUri, mongodb.Credentials{}, mongodb.Options{})
+		user, err := db.GetDBUser(context.Background(), dbName, userName)
+		if err != nil {
+			return err
+		}
+		if !equalUnordered(user.Roles, roles) {
+			return fmt.Errorf("expected user %s.%s to have roles %v, got %v", dbName, userName, roles, user.Roles)
+		}
+		return nil
+	}
+}
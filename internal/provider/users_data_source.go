@@ -21,7 +21,8 @@ func NewUsersDataSource() datasource.DataSource {
 }
 
 type UsersDataSource struct {
-	client *mongodb.Client
+	client   *mongodb.Client
+	timeouts defaultTimeouts
 }
 
 type UsersDataSourceModel struct {
@@ -172,17 +173,18 @@ func (d *UsersDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*mongodb.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *mongodb.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+	d.timeouts = data.timeouts
 }
 
 func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -193,7 +195,7 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	readTimeout, diags := state.Timeouts.Read(ctx, DefaultTimeout)
+	readTimeout, diags := state.Timeouts.Read(ctx, d.timeouts.Read)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -215,9 +217,8 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("Reading MongoDB users",
-			fmt.Sprintf("Failed to get the list of users from MongoDB. Error: %s", err),
-		)
+		addClientError(&resp.Diagnostics, "list users", err)
+		return
 	}
 
 	state.Users = toTypesUserDataSourceSlice(users)
@@ -5,7 +5,13 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -22,3 +28,157 @@ var optionalDatabaseValidators = []validator.String{
 	stringvalidator.RegexMatches(regexp.MustCompile(`^[^\/\\. "$*<>:|?\0]*$`),
 		`MongoDB has restrictions on database name. We're limiting on the Windows restrictions here to be safe. See https://www.mongodb.com/docs/v6.0/reference/limits/#naming-restrictions`),
 }
+
+var collectionValidators = []validator.String{
+	stringvalidator.LengthBetween(1, 255),
+	stringvalidator.RegexMatches(regexp.MustCompile(`^[^$\0]*$`),
+		`MongoDB collection names cannot contain the "$" or null characters. See https://www.mongodb.com/docs/v6.0/reference/limits/#naming-restrictions`),
+	stringvalidator.RegexMatches(regexp.MustCompile(`^[^.]`),
+		`MongoDB collection names cannot start with a dot.`),
+	noPrefixValidator{prefix: "system."},
+}
+
+// durationValidator validates that a string is a valid Go duration, in the
+// same format as the resource timeouts, e.g "30s" or "2h45m".
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (durationValidator) Description(context.Context) string {
+	return "value must be a valid duration, such as \"30s\" or \"2h45m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// noPrefixValidator validates that a string does not start with a prefix.
+type noPrefixValidator struct {
+	prefix string
+}
+
+var _ validator.String = noPrefixValidator{}
+
+func (v noPrefixValidator) Description(context.Context) string {
+	return fmt.Sprintf("value must not start with %q", v.prefix)
+}
+
+func (v noPrefixValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v noPrefixValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if strings.HasPrefix(req.ConfigValue.ValueString(), v.prefix) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// cidrValidator validates that a string is an IP address or a CIDR range,
+// such as "10.0.0.1", "10.0.0.0/16", or "2001:db8::/32".
+type cidrValidator struct{}
+
+var _ validator.String = cidrValidator{}
+
+func (cidrValidator) Description(context.Context) string {
+	return "value must be an IP address or a CIDR range, such as \"10.0.0.0/16\""
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if net.ParseIP(value) != nil {
+		return
+	}
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+var dnAttributeTypeRegex = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*|[0-9]+(\.[0-9]+)*)$`)
+
+// validateDistinguishedName validates that a string is a distinguished name
+// in the RFC 2253 format used by MongoDB for X.509 and LDAP users, such as
+// "CN=myName,OU=myOrgUnit,O=myOrg". MongoDB compares the names as strings,
+// so whitespace around the separators is rejected.
+func validateDistinguishedName(dn string) error {
+	trailing := len(dn) - len(strings.TrimRight(dn, `\`))
+	if trailing%2 == 1 {
+		return errors.New("ends with an unfinished escape sequence")
+	}
+	rdns := splitUnescaped(dn, ',')
+	for i, rdn := range rdns {
+		if rdn == "" {
+			return fmt.Errorf("relative distinguished name #%d is empty", i+1)
+		}
+		if strings.TrimSpace(rdn) != rdn {
+			return fmt.Errorf("relative distinguished name %q must not have whitespace around the comma separators", rdn)
+		}
+		// Multi-valued relative distinguished names are separated by "+".
+		for _, attr := range splitUnescaped(rdn, '+') {
+			attrType, value, ok := strings.Cut(attr, "=")
+			if !ok {
+				return fmt.Errorf("attribute %q must be in the format type=value", attr)
+			}
+			if !dnAttributeTypeRegex.MatchString(attrType) {
+				return fmt.Errorf("attribute %q has an invalid type %q", attr, attrType)
+			}
+			if value == "" {
+				return fmt.Errorf("attribute %q has an empty value", attr)
+			}
+		}
+	}
+	return nil
+}
+
+// splitUnescaped splits a string on a separator that is not escaped by a
+// backslash. The escape sequences are kept in the result.
+func splitUnescaped(s string, sep rune) []string {
+	var result []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			result = append(result, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	return append(result, current.String())
+}
@@ -0,0 +1,374 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RiskIdent/terraform-provider-mongodb-driver/internal/mongodb"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ViewResource{}
+var _ resource.ResourceWithConfigure = &ViewResource{}
+var _ resource.ResourceWithImportState = &ViewResource{}
+
+func NewViewResource() resource.Resource {
+	return &ViewResource{}
+}
+
+// ViewResource defines the resource implementation.
+type ViewResource struct {
+	client   *mongodb.Client
+	timeouts defaultTimeouts
+}
+
+// ViewResourceModel describes the resource data model.
+type ViewResourceModel struct {
+	ID        types.String            `tfsdk:"id"`
+	DB        types.String            `tfsdk:"db"`
+	Name      types.String            `tfsdk:"name"`
+	ViewOn    types.String            `tfsdk:"view_on"`
+	Pipeline  ExtJSON                 `tfsdk:"pipeline"`
+	Collation *CollationResourceModel `tfsdk:"collation"`
+	Timeouts  timeouts.Value          `tfsdk:"timeouts"`
+}
+
+func (v ViewResourceModel) viewAndDB() (string, string, error) {
+	return collectionAndDB(v.ID, v.DB, v.Name)
+}
+
+func (v ViewResourceModel) pipeline() (bson.A, error) {
+	value, err := v.Pipeline.Unmarshal()
+	if err != nil {
+		return nil, err
+	}
+	pipeline, ok := value.(bson.A)
+	if !ok {
+		return nil, errors.New("pipeline must be an array of aggregation stages")
+	}
+	return pipeline, nil
+}
+
+func (v ViewResourceModel) toNewCollection(viewName string) (mongodb.NewCollection, error) {
+	pipeline, err := v.pipeline()
+	if err != nil {
+		return mongodb.NewCollection{}, err
+	}
+	return mongodb.NewCollection{
+		Name: viewName,
+		CollectionOptions: mongodb.CollectionOptions{
+			ViewOn:    v.ViewOn.ValueString(),
+			Pipeline:  pipeline,
+			Collation: v.Collation.toCollation(),
+		},
+	}, nil
+}
+
+// toUpdateCollection returns the collMod command that changes the view from
+// its prior state to the planned state. MongoDB requires both the source
+// and the pipeline when changing either of them.
+func (v ViewResourceModel) toUpdateCollection(ctx context.Context, viewName string, state ViewResourceModel) (mongodb.UpdateCollection, error) {
+	update := mongodb.UpdateCollection{Name: viewName}
+	pipelineEqual, diags := v.Pipeline.StringSemanticEquals(ctx, state.Pipeline)
+	if diags.HasError() {
+		return mongodb.UpdateCollection{}, errors.New("unable to compare pipelines")
+	}
+	if v.ViewOn.Equal(state.ViewOn) && pipelineEqual {
+		return update, nil
+	}
+	pipeline, err := v.pipeline()
+	if err != nil {
+		return mongodb.UpdateCollection{}, err
+	}
+	update.ViewOn = v.ViewOn.ValueString()
+	update.Pipeline = pipeline
+	return update, nil
+}
+
+func (v *ViewResourceModel) applyView(dbName string, coll mongodb.Collection) error {
+	v.ID = types.StringValue(dbName + "." + coll.Name)
+	v.DB = types.StringValue(dbName)
+	v.Name = types.StringValue(coll.Name)
+	v.ViewOn = types.StringValue(coll.Options.ViewOn)
+	pipeline, err := NewExtJSONFromBSON(coll.Options.Pipeline)
+	if err != nil {
+		return fmt.Errorf("pipeline: %w", err)
+	}
+	v.Pipeline = pipeline
+	v.Collation = toTypesCollationResource(v.Collation, coll.Options.Collation)
+	return nil
+}
+
+func (r *ViewResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_view"
+}
+
+func (r *ViewResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "View resource.\n\n" +
+			"A read-only view of the result of an aggregation pipeline on another collection or view. " +
+			"Changes to the source and pipeline are updated in place.\n" +
+			"See: <https://www.mongodb.com/docs/manual/core/views/>",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "View unique ID in MongoDB. Is composed from the `db` and `name` fields.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"db": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Database this view belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: databaseValidators,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the view.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: collectionValidators,
+			},
+			"view_on": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Source collection or view in the same database.",
+				Validators:          collectionValidators,
+			},
+			"pipeline": schema.StringAttribute{
+				CustomType: ExtJSONType{},
+				Required:   true,
+				MarkdownDescription: "Aggregation pipeline of the view, as an array of stages in JSON or MongoDB Extended JSON format, " +
+					"such as `jsonencode([{ \"$match\" = { status = \"active\" } }])`. " +
+					"The pipeline is compared semantically, so formatting does not cause changes.",
+			},
+			"collation": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Default collation of the view. Views do not inherit the collation of their source.\n" +
+					"  See: <https://www.mongodb.com/docs/manual/reference/collation/>",
+				Attributes: collationResourceAttributesSchema,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *ViewResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.timeouts = data.timeouts
+}
+
+func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ViewResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	viewName, dbName, err := data.viewAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve view and database, got error: %s", err))
+		return
+	}
+
+	newView, err := data.toNewCollection(viewName)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pipeline"), "Data Error", fmt.Sprintf("Unable to parse pipeline, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.CreateDBCollection(ctx, dbName, newView)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create view", err)
+		return
+	}
+
+	if err := data.applyView(dbName, coll); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created view")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ViewResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.Read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	viewName, dbName, err := data.viewAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve view and database, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.GetDBCollection(ctx, dbName, viewName)
+	if mongodb.IsNotFound(err) {
+		// The view was dropped outside of Terraform. Removing it from the
+		// state lets Terraform plan to create it again.
+		tflog.Warn(ctx, "view not found, removing from state", map[string]any{
+			"db":   dbName,
+			"view": viewName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read view", err)
+		return
+	}
+	if coll.Type != mongodb.CollectionTypeView {
+		resp.Diagnostics.AddError("Unexpected Collection Type",
+			fmt.Sprintf("Expected %s.%s to be a view, but it is a %s.", dbName, viewName, coll.Type))
+		return
+	}
+
+	if err := data.applyView(dbName, coll); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *ViewResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.Update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	viewName, dbName, err := data.viewAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve view and database, got error: %s", err))
+		return
+	}
+
+	update, err := data.toUpdateCollection(ctx, viewName, *state)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pipeline"), "Data Error", fmt.Sprintf("Unable to parse pipeline, got error: %s", err))
+		return
+	}
+
+	coll, err := r.client.UpdateDBCollection(ctx, dbName, update)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update view", err)
+		return
+	}
+
+	if err := data.applyView(dbName, coll); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to interpret database response, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ViewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ViewResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.Delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	viewName, dbName, err := data.viewAndDB()
+	if err != nil {
+		resp.Diagnostics.AddError("Data Error", fmt.Sprintf("Unable to resolve view and database, got error: %s", err))
+		return
+	}
+
+	if err := r.client.DeleteDBCollection(ctx, dbName, viewName); err != nil && !mongodb.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "drop view", err)
+		return
+	}
+}
+
+func (r *ViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 Risk.Ident GmbH <contact@riskident.com>
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccViewResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "mongodb_view" "example" {
+  db      = "testdb-viewresource"
+  name    = "activeCustomers"
+  view_on = "customers"
+  pipeline = jsonencode([
+    { "$match" = { status = "active" } },
+    { "$project" = { name = 1, email = 1 } },
+  ])
+}
+
+resource "mongodb_role" "example" {
+  db   = "testdb-viewresource"
+  role = "activeCustomersReader"
+  privileges = [
+    {
+      resource = { db = mongodb_view.example.db, collection = mongodb_view.example.name }
+      actions  = ["find"]
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_view.example", "id", "testdb-viewresource.activeCustomers"),
+					resource.TestCheckResourceAttr("mongodb_view.example", "view_on", "customers"),
+					resource.TestCheckResourceAttr("mongodb_role.example", "privileges.0.resource.collection", "activeCustomers"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "mongodb_view.example",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"pipeline"},
+			},
+			// Reformatting the pipeline does not cause changes
+			{
+				Config: providerConfig + `
+resource "mongodb_view" "example" {
+  db       = "testdb-viewresource"
+  name     = "activeCustomers"
+  view_on  = "customers"
+  pipeline = <<-EOT
+    [
+      { "$match": { "status": "active" } },
+      { "$project": { "email": 1, "name": 1 } }
+    ]
+  EOT
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_view.example", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			// Update in place using collMod
+			{
+				Config: providerConfig + `
+resource "mongodb_view" "example" {
+  db      = "testdb-viewresource"
+  name    = "activeCustomers"
+  view_on = "customers"
+  pipeline = jsonencode([
+    { "$match" = { status = "active" } },
+    { "$project" = { name = 1 } },
+  ])
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_view.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_view.example", "view_on", "customers"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}